@@ -0,0 +1,175 @@
+// Package assistantsync publishes assistants declared in YAML/JSON manifests
+// to a PixiGPT account.
+//
+// Each manifest file describes one assistant. Sync matches manifests to live
+// assistants by a stable key stored in the assistant's metadata, computes a
+// plan of creates, updates, deletes and no-ops, and applies it.
+//
+// Example manifest (support.yaml):
+//
+//	key: support
+//	name: Support Bot
+//	instructions_file: prompts/support.md
+//	model: pixi-large
+//	temperature: 0.3
+//	tools_config:
+//	  pixi_tools:
+//	    - name: WebSearch
+//	metadata:
+//	  team: support
+package assistantsync
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/PixiGPT/pixigpt-go/client"
+	"gopkg.in/yaml.v3"
+)
+
+// MetadataKey is the assistant metadata field holding the manifest key.
+const MetadataKey = client.SyncMetadataKey
+
+// Manifest declares the desired state of one assistant.
+//
+// Optional fields left empty (Description, Model, Temperature,
+// EnableThinking) are not managed. A nil ToolsConfig means the server
+// default Pixi tools.
+type Manifest struct {
+	Key              string              `json:"key"` // Defaults to the file name without extension
+	Name             string              `json:"name"`
+	Description      string              `json:"description,omitempty"`
+	Instructions     string              `json:"instructions,omitempty"`
+	InstructionsFile string              `json:"instructions_file,omitempty"` // Relative to the manifest
+	Model            string              `json:"model,omitempty"`
+	Temperature      *float32            `json:"temperature,omitempty"`
+	EnableThinking   *bool               `json:"enable_thinking,omitempty"`
+	ToolsConfig      *client.ToolsConfig `json:"tools_config,omitempty"`
+	Metadata         map[string]string   `json:"metadata,omitempty"`
+
+	// Path is the file the manifest was loaded from.
+	Path string `json:"-"`
+}
+
+// LoadDir reads every *.yaml, *.yml and *.json manifest in dir (not
+// recursive), sorted by key.
+func LoadDir(dir string) ([]Manifest, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifests []Manifest
+	seen := make(map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+
+		m, err := LoadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if prev, ok := seen[m.Key]; ok {
+			return nil, fmt.Errorf("duplicate manifest key %q in %s and %s", m.Key, prev, m.Path)
+		}
+		seen[m.Key] = m.Path
+		manifests = append(manifests, *m)
+	}
+
+	sort.Slice(manifests, func(i, j int) bool { return manifests[i].Key < manifests[j].Key })
+	return manifests, nil
+}
+
+// LoadFile reads a single YAML or JSON manifest and resolves its
+// instructions file.
+func LoadFile(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode YAML (a superset of JSON) generically, then go through JSON so
+	// ToolsConfig keeps its own decoding rules
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	jsonData, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	var m Manifest
+	dec := json.NewDecoder(bytes.NewReader(jsonData))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	m.Path = path
+
+	if m.Key == "" {
+		m.Key = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if m.InstructionsFile != "" {
+		if m.Instructions != "" {
+			return nil, fmt.Errorf("%s: instructions and instructions_file are mutually exclusive", path)
+		}
+		file := m.InstructionsFile
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(filepath.Dir(path), file)
+		}
+		text, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		m.Instructions = string(text)
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// Validate checks the manifest locally.
+func (m *Manifest) Validate() error {
+	if m.Key == "" {
+		return fmt.Errorf("manifest key is required")
+	}
+	if m.Name == "" {
+		return fmt.Errorf("manifest %q: name is required", m.Key)
+	}
+	if m.Instructions == "" {
+		return fmt.Errorf("manifest %q: instructions or instructions_file is required", m.Key)
+	}
+	if _, ok := m.Metadata[MetadataKey]; ok {
+		return fmt.Errorf("manifest %q: metadata key %q is reserved", m.Key, MetadataKey)
+	}
+	if m.ToolsConfig != nil {
+		if err := m.ToolsConfig.ValidateStrict(); err != nil {
+			return fmt.Errorf("manifest %q: %w", m.Key, err)
+		}
+	}
+	return nil
+}
+
+// metadata returns the desired assistant metadata, including the sync key.
+func (m *Manifest) metadata() map[string]string {
+	md := make(map[string]string, len(m.Metadata)+1)
+	for k, v := range m.Metadata {
+		md[k] = v
+	}
+	md[MetadataKey] = m.Key
+	return md
+}
@@ -0,0 +1,377 @@
+package assistantsync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/PixiGPT/pixigpt-go/client"
+)
+
+// API is the subset of *client.Client used by sync.
+type API interface {
+	ListAssistants(ctx context.Context) ([]client.Assistant, error)
+	CreateAssistantWithRequest(ctx context.Context, req client.AssistantCreateRequest) (*client.Assistant, error)
+	ModifyAssistant(ctx context.Context, assistantID string, req client.AssistantUpdateRequest) (*client.Assistant, error)
+	DeleteAssistant(ctx context.Context, assistantID string) error
+}
+
+// Action is the operation planned for one assistant.
+type Action string
+
+// Planned actions.
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+	ActionNoop   Action = "noop"
+)
+
+// Options configures Plan and Apply.
+type Options struct {
+	// Prune deletes managed assistants (those carrying MetadataKey) whose
+	// key has no manifest. Unmanaged assistants are never touched.
+	Prune bool
+	// DryRun makes Apply report the plan without changing anything.
+	DryRun bool
+}
+
+// FieldDiff is a changed assistant field.
+type FieldDiff struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// Change is the planned action for one assistant.
+type Change struct {
+	Action   Action
+	Key      string
+	Manifest *Manifest         // nil for deletes
+	Current  *client.Assistant // nil for creates
+	Diffs    []FieldDiff       // Set for updates
+}
+
+// Plan is the set of changes needed to match the manifests.
+type Plan struct {
+	Changes []Change
+}
+
+// Sync loads the manifests in dir, plans against the live account and
+// applies the plan (unless opts.DryRun). The plan is returned either way.
+func Sync(ctx context.Context, api API, dir string, opts Options) (*Plan, error) {
+	manifests, err := LoadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := MakePlan(ctx, api, manifests, opts)
+	if err != nil {
+		return nil, err
+	}
+	return plan, Apply(ctx, api, plan, opts)
+}
+
+// MakePlan compares manifests against the live assistants.
+func MakePlan(ctx context.Context, api API, manifests []Manifest, opts Options) (*Plan, error) {
+	assistants, err := api.ListAssistants(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	live := make(map[string]*client.Assistant)
+	for i := range assistants {
+		a := &assistants[i]
+		key, ok := a.Metadata[MetadataKey]
+		if !ok {
+			continue
+		}
+		if prev, dup := live[key]; dup {
+			return nil, fmt.Errorf("sync key %q is used by assistants %s and %s", key, prev.ID, a.ID)
+		}
+		live[key] = a
+	}
+
+	plan := &Plan{}
+	wanted := make(map[string]bool)
+	for i := range manifests {
+		m := &manifests[i]
+		if err := m.Validate(); err != nil {
+			return nil, err
+		}
+		if wanted[m.Key] {
+			return nil, fmt.Errorf("duplicate manifest key %q", m.Key)
+		}
+		wanted[m.Key] = true
+
+		current, ok := live[m.Key]
+		if !ok {
+			plan.Changes = append(plan.Changes, Change{Action: ActionCreate, Key: m.Key, Manifest: m})
+			continue
+		}
+
+		diffs := diffAssistant(current, m)
+		action := ActionUpdate
+		if len(diffs) == 0 {
+			action = ActionNoop
+		}
+		plan.Changes = append(plan.Changes, Change{Action: action, Key: m.Key, Manifest: m, Current: current, Diffs: diffs})
+	}
+
+	if opts.Prune {
+		var keys []string
+		for key := range live {
+			if !wanted[key] {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			plan.Changes = append(plan.Changes, Change{Action: ActionDelete, Key: key, Current: live[key]})
+		}
+	}
+
+	return plan, nil
+}
+
+// Apply executes the plan. With opts.DryRun it does nothing.
+func Apply(ctx context.Context, api API, plan *Plan, opts Options) error {
+	if opts.DryRun {
+		return nil
+	}
+
+	for i := range plan.Changes {
+		ch := &plan.Changes[i]
+		var err error
+		switch ch.Action {
+		case ActionCreate:
+			var created *client.Assistant
+			created, err = api.CreateAssistantWithRequest(ctx, createRequest(ch.Manifest))
+			if err == nil {
+				ch.Current = created
+			}
+		case ActionUpdate:
+			var updated *client.Assistant
+			updated, err = api.ModifyAssistant(ctx, ch.Current.ID, updateRequest(ch.Manifest, ch.Diffs))
+			if err == nil {
+				ch.Current = updated
+			}
+		case ActionDelete:
+			err = api.DeleteAssistant(ctx, ch.Current.ID)
+		}
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", ch.Action, ch.Key, err)
+		}
+	}
+	return nil
+}
+
+// HasChanges reports whether the plan contains anything but no-ops.
+func (p *Plan) HasChanges() bool {
+	for _, ch := range p.Changes {
+		if ch.Action != ActionNoop {
+			return true
+		}
+	}
+	return false
+}
+
+// Count returns the number of changes with the given action.
+func (p *Plan) Count(action Action) int {
+	n := 0
+	for _, ch := range p.Changes {
+		if ch.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// String renders the plan as a readable diff.
+func (p *Plan) String() string {
+	var b strings.Builder
+	for _, ch := range p.Changes {
+		switch ch.Action {
+		case ActionCreate:
+			fmt.Fprintf(&b, "+ create %s (%s)\n", ch.Key, ch.Manifest.Name)
+		case ActionUpdate:
+			fmt.Fprintf(&b, "~ update %s (%s)\n", ch.Key, ch.Current.ID)
+			for _, d := range ch.Diffs {
+				writeFieldDiff(&b, d)
+			}
+		case ActionDelete:
+			fmt.Fprintf(&b, "- delete %s (%s)\n", ch.Key, ch.Current.ID)
+		case ActionNoop:
+			fmt.Fprintf(&b, "  unchanged %s (%s)\n", ch.Key, ch.Current.ID)
+		}
+	}
+	fmt.Fprintf(&b, "Plan: %d to create, %d to update, %d to delete, %d unchanged.\n",
+		p.Count(ActionCreate), p.Count(ActionUpdate), p.Count(ActionDelete), p.Count(ActionNoop))
+	return b.String()
+}
+
+// writeFieldDiff renders one field change; multi-line values get a line diff.
+func writeFieldDiff(b *strings.Builder, d FieldDiff) {
+	if !strings.Contains(d.Old, "\n") && !strings.Contains(d.New, "\n") {
+		fmt.Fprintf(b, "    %s: %q -> %q\n", d.Field, d.Old, d.New)
+		return
+	}
+	fmt.Fprintf(b, "    %s:\n", d.Field)
+	for _, line := range diffLines(strings.Split(d.Old, "\n"), strings.Split(d.New, "\n")) {
+		fmt.Fprintf(b, "      %s\n", line)
+	}
+}
+
+// diffAssistant lists the managed fields that differ from the manifest.
+func diffAssistant(a *client.Assistant, m *Manifest) []FieldDiff {
+	var diffs []FieldDiff
+	add := func(field, old, new string) {
+		if old != new {
+			diffs = append(diffs, FieldDiff{Field: field, Old: old, New: new})
+		}
+	}
+
+	add("name", a.Name, m.Name)
+	add("instructions", a.Instructions, m.Instructions)
+	if m.Description != "" {
+		add("description", a.Description, m.Description)
+	}
+	if m.Model != "" {
+		add("model", a.Model, m.Model)
+	}
+	if m.Temperature != nil {
+		add("temperature", formatFloat(a.Temperature), formatFloat(m.Temperature))
+	}
+	if m.EnableThinking != nil {
+		add("enable_thinking", formatBool(a.EnableThinking), formatBool(m.EnableThinking))
+	}
+	add("tools_config", formatToolsConfig(a.ToolsConfig), formatToolsConfig(m.ToolsConfig))
+	add("metadata", formatMetadata(a.Metadata), formatMetadata(m.metadata()))
+	return diffs
+}
+
+// createRequest builds the create request for a manifest.
+func createRequest(m *Manifest) client.AssistantCreateRequest {
+	return client.AssistantCreateRequest{
+		Name:           m.Name,
+		Instructions:   m.Instructions,
+		Description:    m.Description,
+		Model:          m.Model,
+		Temperature:    m.Temperature,
+		EnableThinking: m.EnableThinking,
+		ToolsConfig:    m.ToolsConfig,
+		Metadata:       m.metadata(),
+	}
+}
+
+// updateRequest builds a partial update containing only the changed fields.
+func updateRequest(m *Manifest, diffs []FieldDiff) client.AssistantUpdateRequest {
+	var req client.AssistantUpdateRequest
+	for _, d := range diffs {
+		switch d.Field {
+		case "name":
+			req.Name = &m.Name
+		case "instructions":
+			req.Instructions = &m.Instructions
+		case "description":
+			req.Description = &m.Description
+		case "model":
+			req.Model = &m.Model
+		case "temperature":
+			req.Temperature = m.Temperature
+		case "enable_thinking":
+			req.EnableThinking = m.EnableThinking
+		case "tools_config":
+			if m.ToolsConfig == nil {
+				req.ResetToolsConfig = true
+			} else {
+				req.ToolsConfig = m.ToolsConfig
+			}
+		case "metadata":
+			req.Metadata = m.metadata()
+		}
+	}
+	return req
+}
+
+func formatFloat(f *float32) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(float64(*f), 'g', -1, 32)
+}
+
+func formatBool(v *bool) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatBool(*v)
+}
+
+func formatToolsConfig(tc *client.ToolsConfig) string {
+	if tc == nil {
+		return "(default)"
+	}
+	encoded, err := tc.Encode()
+	if err != nil {
+		return fmt.Sprintf("(invalid: %v)", err)
+	}
+	return encoded
+}
+
+func formatMetadata(md map[string]string) string {
+	keys := make([]string, 0, len(md))
+	for k := range md {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k + "=" + md[k]
+	}
+	return strings.Join(parts, ", ")
+}
+
+// diffLines returns a minimal line diff prefixed with "-", "+" or " ".
+func diffLines(old, new []string) []string {
+	// Longest common subsequence table
+	lcs := make([][]int, len(old)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(new)+1)
+	}
+	for i := len(old) - 1; i >= 0; i-- {
+		for j := len(new) - 1; j >= 0; j-- {
+			if old[i] == new[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out []string
+	i, j := 0, 0
+	for i < len(old) && j < len(new) {
+		switch {
+		case old[i] == new[j]:
+			out = append(out, "  "+old[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, "- "+old[i])
+			i++
+		default:
+			out = append(out, "+ "+new[j])
+			j++
+		}
+	}
+	for ; i < len(old); i++ {
+		out = append(out, "- "+old[i])
+	}
+	for ; j < len(new); j++ {
+		out = append(out, "+ "+new[j])
+	}
+	return out
+}
@@ -0,0 +1,140 @@
+package assistantsync
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PixiGPT/pixigpt-go/client"
+)
+
+// fakeAPI is an in-memory assistant store.
+type fakeAPI struct {
+	assistants []client.Assistant
+	calls      []string
+}
+
+func (f *fakeAPI) ListAssistants(ctx context.Context) ([]client.Assistant, error) {
+	return append([]client.Assistant(nil), f.assistants...), nil
+}
+
+func (f *fakeAPI) CreateAssistantWithRequest(ctx context.Context, req client.AssistantCreateRequest) (*client.Assistant, error) {
+	f.calls = append(f.calls, "create "+req.Metadata[MetadataKey])
+	a := client.Assistant{ID: fmt.Sprintf("asst_%d", len(f.assistants)+1), Name: req.Name, Instructions: req.Instructions, Metadata: req.Metadata}
+	f.assistants = append(f.assistants, a)
+	return &a, nil
+}
+
+func (f *fakeAPI) ModifyAssistant(ctx context.Context, id string, req client.AssistantUpdateRequest) (*client.Assistant, error) {
+	var fields []string
+	if req.Name != nil {
+		fields = append(fields, "name")
+	}
+	if req.Instructions != nil {
+		fields = append(fields, "instructions")
+	}
+	if req.ToolsConfig != nil {
+		fields = append(fields, "tools_config")
+	}
+	f.calls = append(f.calls, "update "+id+" "+strings.Join(fields, ","))
+	return &client.Assistant{ID: id}, nil
+}
+
+func (f *fakeAPI) DeleteAssistant(ctx context.Context, id string) error {
+	f.calls = append(f.calls, "delete "+id)
+	return nil
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSync(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "support.yaml"), `
+name: Support Bot
+instructions_file: support.md
+tools_config:
+  pixi_tools:
+    - name: WebSearch
+      options:
+        max_results: 5
+metadata:
+  team: support
+`)
+	writeFile(t, filepath.Join(dir, "support.md"), "You help customers.\nBe concise.\n")
+	writeFile(t, filepath.Join(dir, "faq.json"), `{"name": "FAQ", "instructions": "Answer FAQs."}`)
+	writeFile(t, filepath.Join(dir, "new.yml"), "name: New\ninstructions: Hi.\n")
+	writeFile(t, filepath.Join(dir, "README.txt"), "ignored")
+
+	tools, err := client.ParseToolsConfig(`{"pixi_tools":[{"name":"WebSearch","options":{"max_results":5}}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := &fakeAPI{assistants: []client.Assistant{
+		{ID: "asst_a", Name: "Support Bot", Instructions: "You help customers.\nBe brief.\n", ToolsConfig: tools,
+			Metadata: map[string]string{MetadataKey: "support", "team": "support"}},
+		{ID: "asst_b", Name: "FAQ", Instructions: "Answer FAQs.", Metadata: map[string]string{MetadataKey: "faq"}},
+		{ID: "asst_c", Name: "Old", Metadata: map[string]string{MetadataKey: "old"}},
+		{ID: "asst_d", Name: "Hand-made"},
+	}}
+
+	ctx := context.Background()
+	plan, err := Sync(ctx, api, dir, Options{Prune: true, DryRun: true})
+	if err != nil {
+		t.Fatalf("Sync (dry run) failed: %v", err)
+	}
+	if len(api.calls) != 0 {
+		t.Errorf("Dry run made calls: %v", api.calls)
+	}
+
+	out := plan.String()
+	t.Logf("Plan:\n%s", out)
+	for _, want := range []string{
+		"+ create new (New)",
+		"~ update support (asst_a)",
+		"- Be brief.",
+		"+ Be concise.",
+		"- delete old (asst_c)",
+		"unchanged faq (asst_b)",
+		"Plan: 1 to create, 1 to update, 1 to delete, 1 unchanged.",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Plan missing %q", want)
+		}
+	}
+	if strings.Contains(out, "asst_d") {
+		t.Error("Plan touches unmanaged assistant")
+	}
+
+	if _, err := Sync(ctx, api, dir, Options{Prune: true}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	want := "create new|update asst_a instructions|delete asst_c"
+	if got := strings.Join(api.calls, "|"); got != want {
+		t.Errorf("Unexpected calls:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	cases := map[string]string{
+		"noname.yaml":   "instructions: x\n",
+		"unknown.yaml":  "name: A\ninstructions: x\ncolour: red\n",
+		"reserved.yaml": "name: A\ninstructions: x\nmetadata:\n  " + MetadataKey + ": y\n",
+		"badtool.yaml":  "name: A\ninstructions: x\ntools_config:\n  pixi_tools:\n    - name: Nope\n",
+	}
+	for name, content := range cases {
+		path := filepath.Join(dir, name)
+		writeFile(t, path, content)
+		if _, err := LoadFile(path); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
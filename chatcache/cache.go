@@ -0,0 +1,449 @@
+// Package chatcache caches PixiGPT chat completions.
+//
+// A Cache wraps CreateChatCompletion. Every cacheable request is keyed by
+// a hash of the whole request; with an Embedder configured, a request that
+// misses can also be served by an earlier one whose last user message is
+// semantically similar, provided the rest of the conversation is identical.
+// Entries expire after a TTL, are namespaced per assistant, and requests
+// using tools or a high temperature (including the server default) bypass
+// the cache. Stores are
+// pluggable: MemoryStore (LRU) or any implementation of Store.
+//
+// Example:
+//
+//	cache := chatcache.New(c, chatcache.NewMemoryStore(10000), chatcache.Options{
+//	    TTL:       24 * time.Hour,
+//	    Embedder:  c, // optional semantic matching
+//	    Threshold: 0.95,
+//	})
+//	resp, err := cache.CreateChatCompletion(ctx, req)
+//	stats := cache.Stats() // hits, semantic hits, misses, bypassed
+package chatcache
+
+import (
+	"container/heap"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/PixiGPT/pixigpt-go/client"
+	"github.com/PixiGPT/pixigpt-go/vectorstore"
+)
+
+// Entry is a cached response.
+type Entry struct {
+	Response  []byte    // JSON-encoded client.ChatCompletionResponse
+	ExpiresAt time.Time // Zero = never
+}
+
+// Store holds entries by key. Implementations must be safe for concurrent
+// use. Get returns nil for missing keys; expiry is checked by the Cache.
+type Store interface {
+	Get(ctx context.Context, key string) (*Entry, error)
+	Set(ctx context.Context, key string, entry *Entry) error
+	Delete(ctx context.Context, key string) error
+}
+
+// Evicter is implemented by stores that drop entries on their own, such as
+// MemoryStore. A Cache with an Embedder subscribes so its semantic index
+// forgets evicted entries.
+type Evicter interface {
+	OnEvict(f func(key string))
+}
+
+// API is the subset of *client.Client wrapped by the cache.
+type API interface {
+	CreateChatCompletion(ctx context.Context, req client.ChatCompletionRequest) (*client.ChatCompletionResponse, error)
+}
+
+// Options configures a Cache.
+type Options struct {
+	// Namespace is mixed into every key; change it to invalidate the cache.
+	// Keys are further namespaced by AssistantID.
+	Namespace string
+
+	TTL          time.Duration            // Entry lifetime, 0 = no expiry
+	AssistantTTL map[string]time.Duration // Per-assistant override of TTL
+
+	// Embedder enables semantic matching of the last user message; nil =
+	// exact matches only. The semantic index lives in process memory and
+	// starts empty; entries are still read from the Store. Index entries
+	// are dropped when their response expires or is evicted (see Evicter).
+	Embedder  vectorstore.Embedder
+	Threshold float32 // Minimum cosine similarity for a semantic hit (default 0.95)
+
+	// Requests with Temperature above MaxTemperature bypass the cache
+	// (default 0.5). Requests with Tools bypass unless CacheTools is set.
+	MaxTemperature float32
+	CacheTools     bool
+	// Temperature 0 leaves the choice to the server: the assistant's own
+	// temperature from AssistantTemperature, else DefaultTemperature
+	// (default 0.6, the server default). With the defaults such requests
+	// bypass the cache.
+	DefaultTemperature   float32
+	AssistantTemperature map[string]float32
+	// Bypass, when set, can exclude further requests.
+	Bypass func(req client.ChatCompletionRequest) bool
+
+	// OnError receives cache errors: store reads and writes, embeddings
+	// and semantic lookups. They never fail the call, which falls through
+	// to the API; nil = ignore such errors.
+	OnError func(err error)
+}
+
+// Stats counts cache outcomes.
+type Stats struct {
+	Hits         int64 // Exact hits
+	SemanticHits int64
+	Misses       int64
+	Bypassed     int64
+}
+
+// Cache is a caching wrapper around CreateChatCompletion.
+type Cache struct {
+	api   API
+	store Store
+	opts  Options
+
+	mu       sync.Mutex
+	stats    Stats
+	indexes  map[string]*vectorstore.Store // Semantic index per context key
+	indexed  map[string]indexEntry         // Store key -> its index entry
+	expiries expiryHeap                    // Indexed keys, soonest expiry first
+}
+
+// indexEntry locates a store key in the semantic indexes.
+type indexEntry struct {
+	indexKey  string
+	expiresAt time.Time
+}
+
+// New creates a cache in front of api.
+func New(api API, store Store, opts Options) *Cache {
+	if opts.Threshold <= 0 {
+		opts.Threshold = 0.95
+	}
+	if opts.MaxTemperature <= 0 {
+		opts.MaxTemperature = 0.5
+	}
+	if opts.DefaultTemperature <= 0 {
+		opts.DefaultTemperature = 0.6
+	}
+	c := &Cache{
+		api:     api,
+		store:   store,
+		opts:    opts,
+		indexes: make(map[string]*vectorstore.Store),
+		indexed: make(map[string]indexEntry),
+	}
+	if e, ok := store.(Evicter); ok && opts.Embedder != nil {
+		e.OnEvict(c.forget)
+	}
+	return c
+}
+
+// Stats returns counts accumulated over the cache's lifetime.
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// Cacheable reports whether req may be served from or stored in the cache.
+func (c *Cache) Cacheable(req client.ChatCompletionRequest) bool {
+	if c.temperature(req) > c.opts.MaxTemperature {
+		return false
+	}
+	if len(req.Tools) > 0 && !c.opts.CacheTools {
+		return false
+	}
+	return c.opts.Bypass == nil || !c.opts.Bypass(req)
+}
+
+// Key returns the exact cache key of req.
+func (c *Cache) Key(req client.ChatCompletionRequest) string {
+	return c.hash("exact", req)
+}
+
+// CreateChatCompletion has the same contract as
+// client.CreateChatCompletion, so a Cache can replace the client wherever
+// an API is expected. Only API errors are returned: the cache is skipped
+// on its own errors, which go to Options.OnError.
+func (c *Cache) CreateChatCompletion(ctx context.Context, req client.ChatCompletionRequest) (*client.ChatCompletionResponse, error) {
+	if !c.Cacheable(req) {
+		c.count(func(s *Stats) { s.Bypassed++ })
+		return c.api.CreateChatCompletion(ctx, req)
+	}
+
+	key := c.Key(req)
+	resp, err := c.load(ctx, key)
+	if err != nil {
+		c.report(err)
+	}
+	if resp != nil {
+		c.count(func(s *Stats) { s.Hits++ })
+		return resp, nil
+	}
+
+	var indexKey, question string
+	var vector []float32
+	if c.opts.Embedder != nil {
+		indexKey, question = c.semanticKey(req)
+	}
+	if question != "" {
+		vector, err = c.embed(ctx, question)
+		if err == nil {
+			resp, err = c.nearest(ctx, indexKey, vector)
+		}
+		if err != nil {
+			c.report(err)
+		}
+		if resp != nil {
+			c.count(func(s *Stats) { s.SemanticHits++ })
+			return resp, nil
+		}
+	}
+
+	c.count(func(s *Stats) { s.Misses++ })
+	resp, err = c.api.CreateChatCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if !storable(resp) {
+		return resp, nil
+	}
+
+	if err := c.save(ctx, req, key, resp, indexKey, vector); err != nil {
+		c.report(err)
+	}
+	return resp, nil
+}
+
+// report passes a cache error to Options.OnError.
+func (c *Cache) report(err error) {
+	if c.opts.OnError != nil {
+		c.opts.OnError(err)
+	}
+}
+
+// save stores resp under key and indexes its question vector, if any.
+func (c *Cache) save(ctx context.Context, req client.ChatCompletionRequest, key string, resp *client.ChatCompletionResponse, indexKey string, vector []float32) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("chat cache encode: %w", err)
+	}
+	entry := &Entry{Response: data}
+	if ttl := c.ttl(req.AssistantID); ttl > 0 {
+		entry.ExpiresAt = time.Now().Add(ttl)
+	}
+	if err := c.store.Set(ctx, key, entry); err != nil {
+		return fmt.Errorf("chat cache set: %w", err)
+	}
+	if vector != nil {
+		c.index(indexKey, key, vector, entry.ExpiresAt)
+	}
+	return nil
+}
+
+// load returns the unexpired response stored under key, or nil.
+func (c *Cache) load(ctx context.Context, key string) (*client.ChatCompletionResponse, error) {
+	entry, err := c.store.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("chat cache get: %w", err)
+	}
+	if entry == nil {
+		return nil, nil
+	}
+	if !entry.ExpiresAt.IsZero() && time.Now().After(entry.ExpiresAt) {
+		if err := c.store.Delete(ctx, key); err != nil {
+			return nil, fmt.Errorf("chat cache delete: %w", err)
+		}
+		c.forget(key)
+		return nil, nil
+	}
+
+	var resp client.ChatCompletionResponse
+	if err := json.Unmarshal(entry.Response, &resp); err != nil {
+		return nil, fmt.Errorf("chat cache decode: %w", err)
+	}
+	return &resp, nil
+}
+
+// nearest returns the cached response of the most similar earlier
+// question in the same context, if it clears the threshold.
+func (c *Cache) nearest(ctx context.Context, indexKey string, vector []float32) (*client.ChatCompletionResponse, error) {
+	c.mu.Lock()
+	c.expire(time.Now())
+	idx := c.indexes[indexKey]
+	c.mu.Unlock()
+	if idx == nil {
+		return nil, nil
+	}
+
+	results, err := idx.Search(vector, 1, nil)
+	if err != nil {
+		return nil, fmt.Errorf("chat cache search: %w", err)
+	}
+	if len(results) == 0 || results[0].Score < c.opts.Threshold {
+		return nil, nil
+	}
+	resp, err := c.load(ctx, results[0].ID)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		c.forget(results[0].ID) // Removed from the store behind our back
+	}
+	return resp, nil
+}
+
+func (c *Cache) index(indexKey, key string, vector []float32, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expire(time.Now())
+
+	idx := c.indexes[indexKey]
+	if idx == nil {
+		idx = vectorstore.New(vectorstore.Options{})
+	}
+	// Dimension mismatches (a changed embedding model) only skip indexing
+	if err := idx.Add(vectorstore.Document{ID: key, Vector: vector}); err != nil {
+		return
+	}
+	c.indexes[indexKey] = idx
+	c.indexed[key] = indexEntry{indexKey: indexKey, expiresAt: expiresAt}
+	if !expiresAt.IsZero() {
+		heap.Push(&c.expiries, expiry{key: key, at: expiresAt})
+	}
+}
+
+// forget removes key from the semantic index.
+func (c *Cache) forget(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.unindex(key)
+}
+
+// unindex removes key from its index, dropping the index once empty.
+// c.mu must be held.
+func (c *Cache) unindex(key string) {
+	entry, ok := c.indexed[key]
+	if !ok {
+		return
+	}
+	delete(c.indexed, key)
+	if idx := c.indexes[entry.indexKey]; idx != nil && idx.Delete(key) > 0 && idx.Len() == 0 {
+		delete(c.indexes, entry.indexKey)
+	}
+}
+
+// expire unindexes every key whose response has expired. c.mu must be
+// held.
+func (c *Cache) expire(now time.Time) {
+	for c.expiries.Len() > 0 && now.After(c.expiries[0].at) {
+		e := heap.Pop(&c.expiries).(expiry)
+		// Skip keys re-indexed with a later expiry since
+		if entry, ok := c.indexed[e.key]; ok && entry.expiresAt.Equal(e.at) {
+			c.unindex(e.key)
+		}
+	}
+}
+
+func (c *Cache) embed(ctx context.Context, text string) ([]float32, error) {
+	resp, err := c.opts.Embedder.CreateEmbedding(ctx, client.NewEmbeddingRequest(text))
+	if err != nil {
+		return nil, fmt.Errorf("chat cache embed: %w", err)
+	}
+	vectors := resp.Vectors()
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("expected 1 embedding, got %d", len(vectors))
+	}
+	return vectors[0], nil
+}
+
+// semanticKey splits req into the key of everything but the last user
+// message, and that message. The question is empty when the last message
+// is not from the user.
+func (c *Cache) semanticKey(req client.ChatCompletionRequest) (indexKey, question string) {
+	n := len(req.Messages)
+	if n == 0 || req.Messages[n-1].Role != "user" {
+		return "", ""
+	}
+	question = req.Messages[n-1].Content
+	req.Messages = req.Messages[:n-1]
+	return c.hash("semantic", req), question
+}
+
+// hash keys a request by namespace, assistant and canonical JSON.
+func (c *Cache) hash(kind string, req client.ChatCompletionRequest) string {
+	body, _ := json.Marshal(req) // Request types always marshal
+	h := sha256.New()
+	for _, part := range []string{c.opts.Namespace, req.AssistantID, kind, fmt.Sprint(req.KeepReasoning)} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// temperature returns the temperature req will be sampled with.
+func (c *Cache) temperature(req client.ChatCompletionRequest) float32 {
+	if req.Temperature != 0 {
+		return req.Temperature
+	}
+	if t, ok := c.opts.AssistantTemperature[req.AssistantID]; ok && req.AssistantID != "" {
+		return t
+	}
+	return c.opts.DefaultTemperature
+}
+
+func (c *Cache) ttl(assistantID string) time.Duration {
+	if ttl, ok := c.opts.AssistantTTL[assistantID]; ok {
+		return ttl
+	}
+	return c.opts.TTL
+}
+
+func (c *Cache) count(f func(*Stats)) {
+	c.mu.Lock()
+	f(&c.stats)
+	c.mu.Unlock()
+}
+
+// expiry is an indexed key with its expiry time.
+type expiry struct {
+	key string
+	at  time.Time
+}
+
+// expiryHeap is a min-heap by expiry time.
+type expiryHeap []expiry
+
+func (h expiryHeap) Len() int            { return len(h) }
+func (h expiryHeap) Less(i, j int) bool  { return h[i].at.Before(h[j].at) }
+func (h expiryHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *expiryHeap) Push(x interface{}) { *h = append(*h, x.(expiry)) }
+func (h *expiryHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// storable reports whether a response is a complete text answer; tool
+// calls and truncated answers are not cached.
+func storable(resp *client.ChatCompletionResponse) bool {
+	if len(resp.Choices) == 0 {
+		return false
+	}
+	for _, choice := range resp.Choices {
+		if len(choice.Message.ToolCalls) > 0 || choice.FinishReason == "length" {
+			return false
+		}
+	}
+	return true
+}
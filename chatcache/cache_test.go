@@ -0,0 +1,278 @@
+package chatcache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/PixiGPT/pixigpt-go/client"
+)
+
+// fakeAPI answers "answer <question>" (or a fixed reply) and embeds known
+// questions.
+type fakeAPI struct {
+	calls   int
+	reply   client.Message
+	vectors map[string][]float32
+}
+
+func (f *fakeAPI) CreateChatCompletion(ctx context.Context, req client.ChatCompletionRequest) (*client.ChatCompletionResponse, error) {
+	f.calls++
+	msg := f.reply
+	if msg.Role == "" {
+		msg = client.Message{Role: "assistant", Content: "answer " + req.Messages[len(req.Messages)-1].Content}
+	}
+	return &client.ChatCompletionResponse{ID: "resp", Choices: []client.ChatCompletionChoice{{
+		Message: msg, FinishReason: "stop",
+	}}}, nil
+}
+
+func (f *fakeAPI) CreateEmbedding(ctx context.Context, req client.EmbeddingRequest) (*client.EmbeddingResponse, error) {
+	v, ok := f.vectors[req.Input.(string)]
+	if !ok {
+		v = []float32{0, 0, 1}
+	}
+	return &client.EmbeddingResponse{Data: []client.EmbeddingData{{Embedding: v}}}, nil
+}
+
+// ask builds a cacheable request with an explicit low temperature.
+func ask(assistantID, question string) client.ChatCompletionRequest {
+	return client.ChatCompletionRequest{
+		AssistantID: assistantID,
+		Messages:    []client.Message{{Role: "user", Content: question}},
+		Temperature: 0.2,
+	}
+}
+
+func TestExactHitsAndNamespaces(t *testing.T) {
+	api := &fakeAPI{}
+	cache := New(api, NewMemoryStore(0), Options{})
+	ctx := context.Background()
+
+	first, err := cache.CreateChatCompletion(ctx, ask("a1", "hours?"))
+	if err != nil {
+		t.Fatalf("CreateChatCompletion failed: %v", err)
+	}
+	second, err := cache.CreateChatCompletion(ctx, ask("a1", "hours?"))
+	if err != nil {
+		t.Fatalf("CreateChatCompletion failed: %v", err)
+	}
+	if api.calls != 1 || second.Choices[0].Message.Content != first.Choices[0].Message.Content {
+		t.Errorf("Expected a cache hit, got %d calls", api.calls)
+	}
+
+	second.Choices[0].Message.Content = "mutated"
+	third, _ := cache.CreateChatCompletion(ctx, ask("a1", "hours?"))
+	if third.Choices[0].Message.Content != "answer hours?" {
+		t.Error("Cached response was mutated through a returned copy")
+	}
+
+	cache.CreateChatCompletion(ctx, ask("a2", "hours?"))
+	if api.calls != 2 {
+		t.Errorf("Assistants should not share entries, got %d calls", api.calls)
+	}
+
+	if got := cache.Stats(); got != (Stats{Hits: 2, Misses: 2}) {
+		t.Errorf("Unexpected stats %+v", got)
+	}
+}
+
+func TestBypassAndTTL(t *testing.T) {
+	api := &fakeAPI{}
+	store := NewMemoryStore(0)
+	cache := New(api, store, Options{TTL: time.Hour, AssistantTTL: map[string]time.Duration{"short": time.Nanosecond}})
+	ctx := context.Background()
+
+	hot := ask("a1", "poem")
+	hot.Temperature = 0.9
+	withTools := ask("a1", "weather")
+	withTools.Tools = []client.Tool{{"type": "function"}}
+	for i := 0; i < 2; i++ {
+		cache.CreateChatCompletion(ctx, hot)
+		cache.CreateChatCompletion(ctx, withTools)
+	}
+	if api.calls != 4 || store.Len() != 0 || cache.Stats().Bypassed != 4 {
+		t.Errorf("Expected bypass, got %d calls, %d entries, %+v", api.calls, store.Len(), cache.Stats())
+	}
+
+	api.calls = 0
+	cache.CreateChatCompletion(ctx, ask("short", "q"))
+	time.Sleep(time.Millisecond)
+	cache.CreateChatCompletion(ctx, ask("short", "q"))
+	if api.calls != 2 {
+		t.Errorf("Expired entry served, got %d calls", api.calls)
+	}
+
+	api.calls = 0
+	api.reply = client.Message{Role: "assistant", ToolCalls: []client.ToolCall{{ID: "call_1"}}}
+	cache.CreateChatCompletion(ctx, ask("a1", "lookup"))
+	cache.CreateChatCompletion(ctx, ask("a1", "lookup"))
+	if api.calls != 2 {
+		t.Errorf("Tool call responses should not be cached, got %d calls", api.calls)
+	}
+}
+
+func TestZeroTemperatureUsesEffectiveTemperature(t *testing.T) {
+	ctx := context.Background()
+	serverDefault := ask("a1", "hours?")
+	serverDefault.Temperature = 0
+	noAssistant := serverDefault
+	noAssistant.AssistantID = ""
+
+	api := &fakeAPI{}
+	cache := New(api, NewMemoryStore(0), Options{})
+	cache.CreateChatCompletion(ctx, serverDefault)
+	cache.CreateChatCompletion(ctx, serverDefault)
+	if api.calls != 2 || cache.Stats().Bypassed != 2 {
+		t.Errorf("Server default temperature should bypass, got %d calls, %+v", api.calls, cache.Stats())
+	}
+
+	api = &fakeAPI{}
+	cache = New(api, NewMemoryStore(0), Options{AssistantTemperature: map[string]float32{"a1": 0.1}})
+	for i := 0; i < 2; i++ {
+		cache.CreateChatCompletion(ctx, serverDefault)
+		cache.CreateChatCompletion(ctx, noAssistant)
+	}
+	if got := cache.Stats(); got != (Stats{Hits: 1, Misses: 1, Bypassed: 2}) {
+		t.Errorf("Expected the assistant's temperature to allow caching, got %+v", got)
+	}
+
+	cache = New(&fakeAPI{}, NewMemoryStore(0), Options{DefaultTemperature: 0.3})
+	if !cache.Cacheable(noAssistant) {
+		t.Error("Expected DefaultTemperature 0.3 to be cacheable")
+	}
+}
+
+func TestSemanticHits(t *testing.T) {
+	api := &fakeAPI{vectors: map[string][]float32{
+		"What are your opening hours?": {1, 0, 0},
+		"when are you open?":           {0.99, 0.05, 0},
+		"how do I get a refund?":       {0, 1, 0},
+	}}
+	cache := New(api, NewMemoryStore(0), Options{Embedder: api, Threshold: 0.9})
+	ctx := context.Background()
+
+	cache.CreateChatCompletion(ctx, ask("a1", "What are your opening hours?"))
+	resp, err := cache.CreateChatCompletion(ctx, ask("a1", "when are you open?"))
+	if err != nil {
+		t.Fatalf("CreateChatCompletion failed: %v", err)
+	}
+	if api.calls != 1 || resp.Choices[0].Message.Content != "answer What are your opening hours?" {
+		t.Errorf("Expected a semantic hit, got %d calls", api.calls)
+	}
+
+	cache.CreateChatCompletion(ctx, ask("a1", "how do I get a refund?"))
+	if api.calls != 2 {
+		t.Errorf("Dissimilar question should miss, got %d calls", api.calls)
+	}
+
+	// Same question after different history is a different context
+	followUp := ask("a1", "when are you open?")
+	followUp.Messages = append([]client.Message{{Role: "user", Content: "hi"}, {Role: "assistant", Content: "hello"}}, followUp.Messages...)
+	cache.CreateChatCompletion(ctx, followUp)
+	if api.calls != 3 {
+		t.Errorf("Different history should miss, got %d calls", api.calls)
+	}
+
+	if got := cache.Stats(); got != (Stats{SemanticHits: 1, Misses: 3}) {
+		t.Errorf("Unexpected stats %+v", got)
+	}
+}
+
+func TestSemanticIndexForgetsEvictedAndExpired(t *testing.T) {
+	api := &fakeAPI{vectors: map[string][]float32{
+		"q1": {1, 0, 0},
+		"q2": {0, 1, 0},
+	}}
+	cache := New(api, NewMemoryStore(1), Options{Embedder: api, AssistantTTL: map[string]time.Duration{"short": time.Nanosecond}})
+	ctx := context.Background()
+
+	cache.CreateChatCompletion(ctx, ask("a1", "q1"))
+	cache.CreateChatCompletion(ctx, ask("a1", "q2")) // Evicts q1
+	if len(cache.indexed) != 1 || cache.indexes[cache.indexed[cache.Key(ask("a1", "q2"))].indexKey].Len() != 1 {
+		t.Errorf("Evicted entry still indexed: %v", cache.indexed)
+	}
+
+	cache = New(api, NewMemoryStore(0), Options{Embedder: api, AssistantTTL: map[string]time.Duration{"short": time.Nanosecond}})
+	cache.CreateChatCompletion(ctx, ask("short", "q1"))
+	cache.CreateChatCompletion(ctx, ask("short", "q2"))
+	time.Sleep(time.Millisecond)
+	cache.CreateChatCompletion(ctx, ask("a1", "q1"))
+	if len(cache.indexed) != 1 || len(cache.indexes) != 1 || len(cache.expiries) != 0 {
+		t.Errorf("Expired entries still indexed: %v", cache.indexed)
+	}
+}
+
+// failingStore misses every key and fails every write.
+type failingStore struct{}
+
+func (failingStore) Get(ctx context.Context, key string) (*Entry, error) {
+	return nil, nil
+}
+
+func (failingStore) Set(ctx context.Context, key string, entry *Entry) error {
+	return errors.New("redis down")
+}
+
+func (failingStore) Delete(ctx context.Context, key string) error {
+	return nil
+}
+
+func TestSetErrorKeepsResponse(t *testing.T) {
+	var reported []error
+	cache := New(&fakeAPI{}, failingStore{}, Options{OnError: func(err error) { reported = append(reported, err) }})
+
+	resp, err := cache.CreateChatCompletion(context.Background(), ask("a1", "hours?"))
+	if err != nil {
+		t.Fatalf("CreateChatCompletion failed: %v", err)
+	}
+	if resp.Choices[0].Message.Content != "answer hours?" {
+		t.Errorf("Unexpected response %+v", resp)
+	}
+	if len(reported) != 1 || reported[0].Error() != "chat cache set: redis down" {
+		t.Errorf("Expected the store error to be reported, got %v", reported)
+	}
+}
+
+// failingEmbedder fails every embedding request.
+type failingEmbedder struct{}
+
+func (failingEmbedder) CreateEmbedding(ctx context.Context, req client.EmbeddingRequest) (*client.EmbeddingResponse, error) {
+	return nil, errors.New("embeddings down")
+}
+
+// brokenStore fails every read and write.
+type brokenStore struct{ failingStore }
+
+func (brokenStore) Get(ctx context.Context, key string) (*Entry, error) {
+	return nil, errors.New("redis down")
+}
+
+func TestCacheErrorsFallThroughToAPI(t *testing.T) {
+	var reported []string
+	onError := func(err error) { reported = append(reported, err.Error()) }
+	api := &fakeAPI{}
+	ctx := context.Background()
+
+	cache := New(api, NewMemoryStore(0), Options{Embedder: failingEmbedder{}, OnError: onError})
+	for i := 0; i < 2; i++ {
+		resp, err := cache.CreateChatCompletion(ctx, ask("a1", "hours?"))
+		if err != nil || resp.Choices[0].Message.Content != "answer hours?" {
+			t.Fatalf("Expected the API response, got %+v (%v)", resp, err)
+		}
+	}
+	// The exact entry still works; only the first call needed an embedding
+	if api.calls != 1 || len(reported) != 1 || reported[0] != "chat cache embed: embeddings down" {
+		t.Errorf("Expected one reported embed error, got %d calls, %q", api.calls, reported)
+	}
+
+	reported = nil
+	cache = New(api, brokenStore{}, Options{OnError: onError})
+	if _, err := cache.CreateChatCompletion(ctx, ask("a1", "hours?")); err != nil {
+		t.Errorf("Store read error failed the call: %v", err)
+	}
+	if len(reported) != 2 || reported[0] != "chat cache get: redis down" {
+		t.Errorf("Expected reported get and set errors, got %q", reported)
+	}
+}
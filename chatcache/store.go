@@ -0,0 +1,47 @@
+package chatcache
+
+import (
+	"context"
+
+	"github.com/PixiGPT/pixigpt-go/internal/lru"
+)
+
+// MemoryStore is an in-memory LRU store.
+type MemoryStore struct {
+	lru *lru.Cache[*Entry]
+}
+
+// NewMemoryStore creates an LRU store holding up to capacity responses
+// (0 = unbounded).
+func NewMemoryStore(capacity int) *MemoryStore {
+	return &MemoryStore{lru: lru.New[*Entry](capacity)}
+}
+
+// Get implements Store.
+func (s *MemoryStore) Get(ctx context.Context, key string) (*Entry, error) {
+	entry, _ := s.lru.Get(key)
+	return entry, nil
+}
+
+// Set implements Store, evicting the least recently used responses.
+func (s *MemoryStore) Set(ctx context.Context, key string, entry *Entry) error {
+	s.lru.Set(key, entry)
+	return nil
+}
+
+// Delete implements Store.
+func (s *MemoryStore) Delete(ctx context.Context, key string) error {
+	s.lru.Delete(key)
+	return nil
+}
+
+// OnEvict implements Evicter: f is called with the key of every response
+// evicted to stay within capacity.
+func (s *MemoryStore) OnEvict(f func(key string)) {
+	s.lru.OnEvict(func(key string, _ *Entry) { f(key) })
+}
+
+// Len returns the number of cached responses.
+func (s *MemoryStore) Len() int {
+	return s.lru.Len()
+}
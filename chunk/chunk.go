@@ -0,0 +1,283 @@
+// Package chunk splits long documents into pieces sized for CreateEmbedding
+// and Rerank.
+//
+// Every splitter returns chunks that are exact slices of the source text,
+// with byte offsets (Text == source[Start:End]) and metadata, so search hits
+// can be traced back to the original document.
+//
+// Example:
+//
+//	splitter := chunk.NewMarkdownSplitter(chunk.Options{
+//	    Size:     300,
+//	    Overlap:  30,
+//	    Metadata: map[string]string{"doc_id": "handbook"},
+//	})
+//	chunks := splitter.Split(markdown)
+//	texts := chunk.Texts(chunks)
+//	res, err := c.EmbedAll(ctx, texts, client.EmbedOptions{})
+package chunk
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/PixiGPT/pixigpt-go/client"
+)
+
+// Chunk is a piece of a source document.
+type Chunk struct {
+	Index    int               // Position among the document's chunks
+	Text     string            // Source[Start:End]
+	Start    int               // Byte offset in the source
+	End      int               // Byte offset in the source (exclusive)
+	Tokens   int               // Estimated tokens
+	Metadata map[string]string // Options.Metadata plus splitter fields (e.g. "heading")
+}
+
+// Options configures a splitter.
+type Options struct {
+	Size     int                   // Max tokens per chunk (default 256)
+	Overlap  int                   // Tokens repeated from the previous chunk (default 0)
+	Counter  func(text string) int // Token counter (default client.EstimateTokens)
+	Metadata map[string]string     // Copied into every chunk
+}
+
+// Splitter splits a document into chunks.
+type Splitter interface {
+	Split(text string) []Chunk
+}
+
+func (o Options) withDefaults() Options {
+	if o.Size <= 0 {
+		o.Size = 256
+	}
+	if o.Overlap < 0 || o.Overlap >= o.Size {
+		o.Overlap = 0
+	}
+	if o.Counter == nil {
+		o.Counter = client.EstimateTokens
+	}
+	return o
+}
+
+// Texts returns the text of each chunk, e.g. for EmbedAll or Rerank.
+func Texts(chunks []Chunk) []string {
+	texts := make([]string, len(chunks))
+	for i, c := range chunks {
+		texts[i] = c.Text
+	}
+	return texts
+}
+
+// span is a half-open byte range of the source.
+type span struct {
+	start, end int
+}
+
+// TokenSplitter packs words into chunks of a fixed token size.
+type TokenSplitter struct {
+	opts Options
+}
+
+// NewTokenSplitter creates a fixed-size splitter that breaks between words.
+func NewTokenSplitter(opts Options) *TokenSplitter {
+	return &TokenSplitter{opts: opts.withDefaults()}
+}
+
+// Split implements Splitter.
+func (s *TokenSplitter) Split(text string) []Chunk {
+	var spans []span
+	for _, w := range words(text, 0, len(text)) {
+		spans = append(spans, hardSplit(text, w, s.opts)...)
+	}
+	return number(merge(text, spans, s.opts, nil))
+}
+
+// RecursiveSplitter splits on the first separator that yields pieces small
+// enough, falling back to finer separators for oversized pieces.
+type RecursiveSplitter struct {
+	opts       Options
+	separators []string
+}
+
+// DefaultSeparators split by paragraph, line, sentence, then word.
+var DefaultSeparators = []string{"\n\n", "\n", ". ", "? ", "! ", " "}
+
+// NewRecursiveSplitter creates a recursive splitter. With no separators
+// DefaultSeparators are used.
+func NewRecursiveSplitter(opts Options, separators ...string) *RecursiveSplitter {
+	if len(separators) == 0 {
+		separators = DefaultSeparators
+	}
+	return &RecursiveSplitter{opts: opts.withDefaults(), separators: separators}
+}
+
+// Split implements Splitter.
+func (s *RecursiveSplitter) Split(text string) []Chunk {
+	spans := splitRecursive(text, span{0, len(text)}, s.separators, s.opts)
+	return number(merge(text, spans, s.opts, nil))
+}
+
+// splitRecursive breaks sp into pieces no larger than opts.Size, trying
+// separators in order. Separators stay attached to the preceding piece so
+// pieces stay contiguous.
+func splitRecursive(text string, sp span, separators []string, opts Options) []span {
+	if opts.Counter(text[sp.start:sp.end]) <= opts.Size {
+		return []span{sp}
+	}
+	if len(separators) == 0 {
+		return hardSplit(text, sp, opts)
+	}
+
+	sep, rest := separators[0], separators[1:]
+	var pieces []span
+	start := sp.start
+	for start < sp.end {
+		i := strings.Index(text[start:sp.end], sep)
+		if i < 0 {
+			pieces = append(pieces, span{start, sp.end})
+			break
+		}
+		end := start + i + len(sep)
+		pieces = append(pieces, span{start, end})
+		start = end
+	}
+	if len(pieces) == 1 {
+		return splitRecursive(text, sp, rest, opts)
+	}
+
+	var out []span
+	for _, p := range pieces {
+		out = append(out, splitRecursive(text, p, rest, opts)...)
+	}
+	return out
+}
+
+// hardSplit cuts an unbreakable span into pieces of about opts.Size
+// tokens, on rune boundaries.
+func hardSplit(text string, sp span, opts Options) []span {
+	if opts.Counter(text[sp.start:sp.end]) <= opts.Size {
+		return []span{sp}
+	}
+	// Rune boundaries, so pieces never split a character
+	var bounds []int
+	for i := range text[sp.start:sp.end] {
+		bounds = append(bounds, sp.start+i)
+	}
+	bounds = append(bounds, sp.end)
+
+	var out []span
+	for a := 0; a < len(bounds)-1; {
+		// First end that no longer fits; keep at least one rune
+		rest := bounds[a+1:]
+		n := sort.Search(len(rest), func(i int) bool {
+			return opts.Counter(text[bounds[a]:rest[i]]) > opts.Size
+		})
+		b := a + max(n, 1)
+		out = append(out, span{bounds[a], bounds[b]})
+		a = b
+	}
+	return out
+}
+
+// words splits a range into words, each with its trailing whitespace.
+func words(text string, start, end int) []span {
+	var out []span
+	wordStart := start
+	inSpace := false
+	for i, r := range text[start:end] {
+		pos := start + i
+		if unicode.IsSpace(r) {
+			inSpace = true
+			continue
+		}
+		if inSpace && pos > wordStart {
+			out = append(out, span{wordStart, pos})
+			wordStart = pos
+		}
+		inSpace = false
+	}
+	if wordStart < end {
+		out = append(out, span{wordStart, end})
+	}
+	return out
+}
+
+// merge packs contiguous spans into chunks of at most opts.Size tokens,
+// starting each chunk with about opts.Overlap tokens of the previous one.
+func merge(text string, spans []span, opts Options, metadata map[string]string) []Chunk {
+	tokens := make([]int, len(spans))
+	for i, sp := range spans {
+		tokens[i] = opts.Counter(text[sp.start:sp.end])
+	}
+
+	var chunks []Chunk
+	for i := 0; i < len(spans); {
+		j, total := i, 0
+		for j < len(spans) && (j == i || total+tokens[j] <= opts.Size) {
+			total += tokens[j]
+			j++
+		}
+		if c, ok := makeChunk(text, spans[i].start, spans[j-1].end, opts, metadata); ok {
+			chunks = append(chunks, c)
+		}
+		if j == len(spans) {
+			break
+		}
+
+		// Step back over up to Overlap tokens, always making progress
+		next, overlap := j, 0
+		for next-1 > i && overlap+tokens[next-1] <= opts.Overlap {
+			next--
+			overlap += tokens[next]
+		}
+		i = next
+	}
+	return chunks
+}
+
+// makeChunk trims whitespace off a range; empty ranges are dropped.
+func makeChunk(text string, start, end int, opts Options, metadata map[string]string) (Chunk, bool) {
+	for start < end {
+		r, size := utf8.DecodeRuneInString(text[start:end])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		start += size
+	}
+	for end > start {
+		r, size := utf8.DecodeLastRuneInString(text[start:end])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		end -= size
+	}
+	if start == end {
+		return Chunk{}, false
+	}
+
+	md := make(map[string]string, len(opts.Metadata)+len(metadata))
+	for k, v := range opts.Metadata {
+		md[k] = v
+	}
+	for k, v := range metadata {
+		md[k] = v
+	}
+	return Chunk{
+		Text:     text[start:end],
+		Start:    start,
+		End:      end,
+		Tokens:   opts.Counter(text[start:end]),
+		Metadata: md,
+	}, true
+}
+
+// number sets chunk indexes.
+func number(chunks []Chunk) []Chunk {
+	for i := range chunks {
+		chunks[i].Index = i
+	}
+	return chunks
+}
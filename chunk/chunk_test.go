@@ -0,0 +1,139 @@
+package chunk
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// wordCount counts whitespace-separated words, for predictable tests.
+func wordCount(s string) int { return len(strings.Fields(s)) }
+
+// checkOffsets verifies the chunk invariants shared by all splitters.
+func checkOffsets(t *testing.T, text string, chunks []Chunk, size int) {
+	t.Helper()
+	for i, c := range chunks {
+		if c.Index != i {
+			t.Errorf("Chunk %d has index %d", i, c.Index)
+		}
+		if text[c.Start:c.End] != c.Text {
+			t.Errorf("Chunk %d text does not match offsets", i)
+		}
+		if c.Tokens > size {
+			t.Errorf("Chunk %d has %d tokens, max %d: %q", i, c.Tokens, size, c.Text)
+		}
+		if !utf8.ValidString(c.Text) {
+			t.Errorf("Chunk %d splits a rune", i)
+		}
+	}
+}
+
+func TestTokenSplitterOverlap(t *testing.T) {
+	text := "one two three four five six seven eight nine ten"
+	chunks := NewTokenSplitter(Options{Size: 4, Overlap: 1, Counter: wordCount}).Split(text)
+	checkOffsets(t, text, chunks, 4)
+
+	want := []string{
+		"one two three four",
+		"four five six seven",
+		"seven eight nine ten",
+	}
+	if got := Texts(chunks); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestTokenSplitterHardSplitsLongWords(t *testing.T) {
+	text := strings.Repeat("é", 100) + " tail"
+	chunks := NewTokenSplitter(Options{Size: 10}).Split(text)
+	checkOffsets(t, text, chunks, 10)
+	if len(chunks) < 5 {
+		t.Errorf("Expected the long word to be split, got %d chunks", len(chunks))
+	}
+}
+
+func TestRecursiveSplitterPrefersParagraphs(t *testing.T) {
+	text := "First paragraph has five words.\n\nSecond paragraph also has five.\n\nThird one. It has two sentences here."
+	chunks := NewRecursiveSplitter(Options{
+		Size:     6,
+		Counter:  wordCount,
+		Metadata: map[string]string{"doc": "d1"},
+	}).Split(text)
+	checkOffsets(t, text, chunks, 6)
+
+	want := []string{
+		"First paragraph has five words.",
+		"Second paragraph also has five.",
+		"Third one.",
+		"It has two sentences here.",
+	}
+	if got := Texts(chunks); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if chunks[3].Metadata["doc"] != "d1" {
+		t.Errorf("Metadata not copied: %v", chunks[3].Metadata)
+	}
+}
+
+func TestMarkdownSplitterHeadings(t *testing.T) {
+	text := `Intro text.
+
+# Install
+
+General steps.
+
+## Linux
+
+Run the script.
+
+` + "```sh\n# not a heading\n./install.sh\n```" + `
+
+## macOS
+
+Use brew.
+
+# Usage
+
+Call the API.
+`
+	chunks := NewMarkdownSplitter(Options{Size: 50}).Split(text)
+	checkOffsets(t, text, chunks, 50)
+
+	var headings []string
+	for _, c := range chunks {
+		headings = append(headings, c.Metadata["heading"])
+	}
+	want := []string{"", "Install", "Install > Linux", "Install > macOS", "Usage"}
+	if strings.Join(headings, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected headings %q, got %q", want, headings)
+	}
+	if !strings.Contains(chunks[2].Text, "# not a heading") {
+		t.Errorf("Fenced code should stay in its section: %q", chunks[2].Text)
+	}
+}
+
+func TestCodeSplitterKeepsDeclarations(t *testing.T) {
+	text := `package main
+
+func a() {
+	println("a")
+
+	println("a again")
+}
+
+func b() {
+	println("b")
+}
+`
+	chunks := NewCodeSplitter(Options{Size: 8, Counter: wordCount}).Split(text)
+	checkOffsets(t, text, chunks, 8)
+
+	for _, c := range chunks {
+		if strings.Contains(c.Text, "func a") && !strings.Contains(c.Text, `println("a again")`) {
+			t.Errorf("func a was split: %q", c.Text)
+		}
+		if strings.Contains(c.Text, "func b") && strings.Contains(c.Text, "func a") {
+			t.Errorf("Declarations over the size limit should not be packed: %q", c.Text)
+		}
+	}
+}
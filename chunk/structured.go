@@ -0,0 +1,160 @@
+package chunk
+
+import "strings"
+
+// MarkdownSplitter splits Markdown by heading, so chunks never span two
+// sections. Each chunk's "heading" metadata holds the heading path, e.g.
+// "Install > Linux". Headings inside fenced code blocks are ignored.
+type MarkdownSplitter struct {
+	opts Options
+}
+
+// NewMarkdownSplitter creates a heading-aware Markdown splitter.
+func NewMarkdownSplitter(opts Options) *MarkdownSplitter {
+	return &MarkdownSplitter{opts: opts.withDefaults()}
+}
+
+var markdownSeparators = []string{"\n\n", "\n", ". ", " "}
+
+// Split implements Splitter.
+func (s *MarkdownSplitter) Split(text string) []Chunk {
+	type section struct {
+		span
+		heading string
+	}
+
+	var sections []section
+	var path []string // Heading titles by level
+	current := section{span: span{0, 0}}
+	fence := ""
+
+	for _, line := range lines(text) {
+		content := strings.TrimRight(text[line.start:line.end], "\r\n")
+		trimmed := strings.TrimSpace(content)
+
+		switch {
+		case fence != "":
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			fence = trimmed[:3]
+		default:
+			if level, title, ok := parseHeading(content); ok {
+				current.end = line.start
+				sections = append(sections, current)
+
+				if len(path) >= level {
+					path = path[:level-1]
+				}
+				for len(path) < level-1 {
+					path = append(path, "")
+				}
+				path = append(path, title)
+				current = section{span: span{line.start, line.start}, heading: joinPath(path)}
+			}
+		}
+	}
+	current.end = len(text)
+	sections = append(sections, current)
+
+	var chunks []Chunk
+	for _, sec := range sections {
+		if sec.start == sec.end {
+			continue
+		}
+		spans := splitRecursive(text, sec.span, markdownSeparators, s.opts)
+		chunks = append(chunks, merge(text, spans, s.opts, map[string]string{"heading": sec.heading})...)
+	}
+	return number(chunks)
+}
+
+// parseHeading recognizes ATX headings ("## Title").
+func parseHeading(line string) (level int, title string, ok bool) {
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || (level < len(line) && line[level] != ' ' && line[level] != '\t') {
+		return 0, "", false
+	}
+	title = strings.TrimSpace(strings.TrimRight(strings.TrimSpace(line[level:]), "#"))
+	return level, title, true
+}
+
+func joinPath(path []string) string {
+	var parts []string
+	for _, p := range path {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, " > ")
+}
+
+// CodeSplitter splits source code at top-level declarations (a
+// non-indented line after a blank line), packing small declarations
+// together and splitting large ones by blank lines, then lines.
+type CodeSplitter struct {
+	opts Options
+}
+
+// NewCodeSplitter creates a code-aware splitter.
+func NewCodeSplitter(opts Options) *CodeSplitter {
+	return &CodeSplitter{opts: opts.withDefaults()}
+}
+
+var codeSeparators = []string{"\n\n", "\n", " "}
+
+// Split implements Splitter.
+func (s *CodeSplitter) Split(text string) []Chunk {
+	var blocks []span
+	start := 0
+	prevBlank := false
+	for _, line := range lines(text) {
+		content := text[line.start:line.end]
+		blank := strings.TrimSpace(content) == ""
+		if prevBlank && !blank && line.start > start && isTopLevel(content) {
+			blocks = append(blocks, span{start, line.start})
+			start = line.start
+		}
+		prevBlank = blank
+	}
+	if start < len(text) {
+		blocks = append(blocks, span{start, len(text)})
+	}
+
+	var spans []span
+	for _, b := range blocks {
+		spans = append(spans, splitRecursive(text, b, codeSeparators, s.opts)...)
+	}
+	return number(merge(text, spans, s.opts, nil))
+}
+
+// isTopLevel reports whether a line starts a new top-level statement
+// rather than closing one.
+func isTopLevel(line string) bool {
+	if line == "" || line[0] == ' ' || line[0] == '\t' {
+		return false
+	}
+	switch line[0] {
+	case '}', ')', ']':
+		return false
+	}
+	return true
+}
+
+// lines returns the line spans of text, each including its newline.
+func lines(text string) []span {
+	var out []span
+	start := 0
+	for start < len(text) {
+		i := strings.IndexByte(text[start:], '\n')
+		if i < 0 {
+			out = append(out, span{start, len(text)})
+			break
+		}
+		out = append(out, span{start, start + i + 1})
+		start += i + 1
+	}
+	return out
+}
@@ -30,13 +30,40 @@ func (c *Client) GetAssistant(ctx context.Context, assistantID string) (*Assista
 }
 
 // CreateAssistant creates a new assistant.
-func (c *Client) CreateAssistant(ctx context.Context, name, instructions string, toolsConfig *string) (*Assistant, error) {
+//
+// toolsConfig is validated locally; pass nil for the default Pixi tools.
+func (c *Client) CreateAssistant(ctx context.Context, name, instructions string, toolsConfig *ToolsConfig) (*Assistant, error) {
+	return c.CreateAssistantWithRequest(ctx, AssistantCreateRequest{
+		Name:         name,
+		Instructions: instructions,
+		ToolsConfig:  toolsConfig,
+	})
+}
+
+// CreateAssistantWithRequest creates a new assistant with model, defaults
+// and metadata.
+func (c *Client) CreateAssistantWithRequest(ctx context.Context, req AssistantCreateRequest) (*Assistant, error) {
 	reqBody := map[string]interface{}{
-		"name":         name,
-		"instructions": instructions,
+		"name":         req.Name,
+		"instructions": req.Instructions,
+	}
+	if req.Description != "" {
+		reqBody["description"] = req.Description
+	}
+	if req.Model != "" {
+		reqBody["model"] = req.Model
+	}
+	if req.Temperature != nil {
+		reqBody["temperature"] = *req.Temperature
 	}
-	if toolsConfig != nil {
-		reqBody["tools_config"] = *toolsConfig
+	if req.EnableThinking != nil {
+		reqBody["enable_thinking"] = *req.EnableThinking
+	}
+	if req.Metadata != nil {
+		reqBody["metadata"] = req.Metadata
+	}
+	if err := setToolsConfig(reqBody, req.ToolsConfig); err != nil {
+		return nil, err
 	}
 
 	body, err := json.Marshal(reqBody)
@@ -52,14 +79,58 @@ func (c *Client) CreateAssistant(ctx context.Context, name, instructions string,
 	return &assistant, nil
 }
 
-// UpdateAssistant updates an existing assistant.
-func (c *Client) UpdateAssistant(ctx context.Context, assistantID, name, instructions string, toolsConfig *string) (*Assistant, error) {
-	reqBody := map[string]interface{}{
-		"name":         name,
-		"instructions": instructions,
+// UpdateAssistant updates an existing assistant's name, instructions and
+// (if non-nil) tools config. Use ModifyAssistant to change only some fields.
+//
+// toolsConfig is validated locally; pass nil to leave it unchanged.
+func (c *Client) UpdateAssistant(ctx context.Context, assistantID, name, instructions string, toolsConfig *ToolsConfig) (*Assistant, error) {
+	return c.ModifyAssistant(ctx, assistantID, AssistantUpdateRequest{
+		Name:         &name,
+		Instructions: &instructions,
+		ToolsConfig:  toolsConfig,
+	})
+}
+
+// ModifyAssistant applies a partial update: only the non-nil fields of req
+// are sent, so other settings are never clobbered.
+//
+// Example:
+//
+//	thinking := false
+//	assistant, err := client.ModifyAssistant(ctx, assistantID, AssistantUpdateRequest{
+//	    EnableThinking: &thinking,
+//	})
+func (c *Client) ModifyAssistant(ctx context.Context, assistantID string, req AssistantUpdateRequest) (*Assistant, error) {
+	reqBody := map[string]interface{}{}
+	if req.Name != nil {
+		reqBody["name"] = *req.Name
+	}
+	if req.Instructions != nil {
+		reqBody["instructions"] = *req.Instructions
+	}
+	if req.Description != nil {
+		reqBody["description"] = *req.Description
+	}
+	if req.Model != nil {
+		reqBody["model"] = *req.Model
+	}
+	if req.Temperature != nil {
+		reqBody["temperature"] = *req.Temperature
 	}
-	if toolsConfig != nil {
-		reqBody["tools_config"] = *toolsConfig
+	if req.EnableThinking != nil {
+		reqBody["enable_thinking"] = *req.EnableThinking
+	}
+	if req.Metadata != nil {
+		reqBody["metadata"] = req.Metadata
+	}
+	if req.ResetToolsConfig {
+		if req.ToolsConfig != nil {
+			return nil, fmt.Errorf("ResetToolsConfig and ToolsConfig are mutually exclusive")
+		}
+		reqBody["tools_config"] = nil
+	}
+	if err := setToolsConfig(reqBody, req.ToolsConfig); err != nil {
+		return nil, err
 	}
 
 	body, err := json.Marshal(reqBody)
@@ -75,6 +146,61 @@ func (c *Client) UpdateAssistant(ctx context.Context, assistantID, name, instruc
 	return &assistant, nil
 }
 
+// EnableAssistantTool enables a built-in Pixi tool on an existing assistant,
+// replacing its options if it is already enabled.
+func (c *Client) EnableAssistantTool(ctx context.Context, assistantID, tool string, options map[string]interface{}) (*Assistant, error) {
+	return c.editToolsConfig(ctx, assistantID, func(tc *ToolsConfig) error {
+		tc.EnableTool(tool, options)
+		return nil
+	})
+}
+
+// DisableAssistantTool disables a Pixi tool or custom function on an
+// existing assistant.
+func (c *Client) DisableAssistantTool(ctx context.Context, assistantID, tool string) (*Assistant, error) {
+	return c.editToolsConfig(ctx, assistantID, func(tc *ToolsConfig) error {
+		if !tc.DisableTool(tool) {
+			return fmt.Errorf("tool %q is not enabled on assistant %s", tool, assistantID)
+		}
+		return nil
+	})
+}
+
+// editToolsConfig applies edit to an assistant's tools config and saves it.
+// Assistants on the server default start from DefaultToolsConfig.
+func (c *Client) editToolsConfig(ctx context.Context, assistantID string, edit func(*ToolsConfig) error) (*Assistant, error) {
+	assistant, err := c.GetAssistant(ctx, assistantID)
+	if err != nil {
+		return nil, err
+	}
+
+	tc := assistant.ToolsConfig
+	if tc == nil {
+		tc = DefaultToolsConfig()
+	}
+	if err := edit(tc); err != nil {
+		return nil, err
+	}
+
+	return c.ModifyAssistant(ctx, assistantID, AssistantUpdateRequest{ToolsConfig: tc})
+}
+
+// setToolsConfig validates and encodes toolsConfig into a request body.
+func setToolsConfig(reqBody map[string]interface{}, toolsConfig *ToolsConfig) error {
+	if toolsConfig == nil {
+		return nil
+	}
+	if err := toolsConfig.Validate(); err != nil {
+		return err
+	}
+	encoded, err := toolsConfig.Encode()
+	if err != nil {
+		return err
+	}
+	reqBody["tools_config"] = encoded
+	return nil
+}
+
 // DeleteAssistant deletes an assistant.
 func (c *Client) DeleteAssistant(ctx context.Context, assistantID string) error {
 	return c.doRequest(ctx, "DELETE", "/assistants/"+assistantID, nil, nil)
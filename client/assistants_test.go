@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestModifyAssistantSendsOnlySetFields(t *testing.T) {
+	var got map[string]json.RawMessage
+	var gotMethod string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		json.NewDecoder(r.Body).Decode(&got)
+		io.WriteString(w, `{"id":"asst_1","name":"Helper","model":"pixi-large","temperature":0.3,"enable_thinking":false,"metadata":{"team":"support"}}`)
+	}))
+	defer srv.Close()
+
+	c := New("test-key", srv.URL)
+	thinking := false
+	a, err := c.ModifyAssistant(context.Background(), "asst_1", AssistantUpdateRequest{
+		EnableThinking:   &thinking,
+		Metadata:         map[string]string{},
+		ResetToolsConfig: true,
+	})
+	if err != nil {
+		t.Fatalf("ModifyAssistant failed: %v", err)
+	}
+
+	if gotMethod != "PUT" {
+		t.Errorf("Expected PUT, got %s", gotMethod)
+	}
+	if len(got) != 3 {
+		t.Errorf("Expected exactly 3 fields, got %v", got)
+	}
+	if string(got["enable_thinking"]) != "false" {
+		t.Errorf("Unexpected enable_thinking: %s", got["enable_thinking"])
+	}
+	if string(got["metadata"]) != "{}" {
+		t.Errorf("Expected empty metadata to clear, got %s", got["metadata"])
+	}
+	if string(got["tools_config"]) != "null" {
+		t.Errorf("Expected tools_config null, got %s", got["tools_config"])
+	}
+	if _, ok := got["name"]; ok {
+		t.Error("Name sent although not set")
+	}
+
+	if a.Model != "pixi-large" || a.Temperature == nil || *a.Temperature != 0.3 || a.Metadata["team"] != "support" {
+		t.Errorf("Unexpected assistant: %+v", a)
+	}
+}
+
+func TestCreateAssistantValidatesToolsConfig(t *testing.T) {
+	c := New("test-key", "http://127.0.0.1:9")
+	tools := &ToolsConfig{Functions: []FunctionDefinition{{Name: "not a name"}}}
+
+	_, err := c.CreateAssistantWithRequest(context.Background(), AssistantCreateRequest{
+		Name:        "Broken",
+		ToolsConfig: tools,
+	})
+	if err == nil || !strings.Contains(err.Error(), "tools_config") {
+		t.Fatalf("Expected validation error, got %v", err)
+	}
+}
@@ -0,0 +1,309 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// AssistantBundleVersion is the bundle format version written by
+// ExportAssistants.
+const AssistantBundleVersion = 1
+
+// AssistantBundle is a portable, versioned snapshot of assistants (and
+// optionally their threads) used to move them between accounts.
+type AssistantBundle struct {
+	Version    int                `json:"version"`
+	ExportedAt int64              `json:"exported_at"`
+	Assistants []BundledAssistant `json:"assistants"`
+}
+
+// BundledAssistant is an exported assistant with its threads.
+type BundledAssistant struct {
+	Assistant Assistant       `json:"assistant"`
+	Threads   []BundledThread `json:"threads,omitempty"`
+}
+
+// BundledThread is an exported thread with all of its messages, oldest first.
+type BundledThread struct {
+	Thread   Thread          `json:"thread"`
+	Messages []ThreadMessage `json:"messages"`
+}
+
+// ExportOptions configures ExportAssistants.
+type ExportOptions struct {
+	AssistantIDs   []string // Assistants to export, empty = all
+	IncludeThreads bool     // Also export threads from ListAssistantThreads
+	ThreadLimit    int      // Max threads per assistant, 0 = server default
+}
+
+// ConflictPolicy decides what ImportAssistants does when an assistant with
+// the same name already exists.
+type ConflictPolicy string
+
+// Conflict policies.
+const (
+	ConflictSkip      ConflictPolicy = "skip"      // Keep the existing assistant (default)
+	ConflictOverwrite ConflictPolicy = "overwrite" // Update the existing assistant in place
+	ConflictRename    ConflictPolicy = "rename"    // Create a copy named "Name (2)", "Name (3)", ...
+)
+
+// ImportOptions configures ImportAssistants.
+type ImportOptions struct {
+	OnConflict    ConflictPolicy // "" = ConflictSkip
+	ImportThreads bool           // Recreate bundled threads with CreateMessagesBulk
+}
+
+// ImportResult reports what ImportAssistants did.
+type ImportResult struct {
+	Created []Assistant
+	Updated []Assistant
+	Skipped []Assistant // Existing assistants left untouched
+	// AssistantIDs maps bundle assistant IDs to destination IDs.
+	AssistantIDs map[string]string
+	// ThreadIDs maps bundle thread IDs to the recreated thread IDs.
+	ThreadIDs map[string]string
+}
+
+// ExportAssistants snapshots assistants into a bundle.
+//
+// Example (promote from staging to production):
+//
+//	bundle, err := staging.ExportAssistants(ctx, ExportOptions{})
+//	result, err := production.ImportAssistants(ctx, bundle, ImportOptions{
+//	    OnConflict: ConflictOverwrite,
+//	})
+func (c *Client) ExportAssistants(ctx context.Context, opts ExportOptions) (*AssistantBundle, error) {
+	var assistants []Assistant
+	if len(opts.AssistantIDs) == 0 {
+		all, err := c.ListAssistants(ctx)
+		if err != nil {
+			return nil, err
+		}
+		assistants = all
+	} else {
+		for _, id := range opts.AssistantIDs {
+			a, err := c.GetAssistant(ctx, id)
+			if err != nil {
+				return nil, fmt.Errorf("export assistant %s: %w", id, err)
+			}
+			assistants = append(assistants, *a)
+		}
+	}
+
+	bundle := &AssistantBundle{
+		Version:    AssistantBundleVersion,
+		ExportedAt: time.Now().Unix(),
+	}
+	for _, a := range assistants {
+		entry := BundledAssistant{Assistant: a}
+		if opts.IncludeThreads {
+			threads, err := c.ListAssistantThreads(ctx, a.ID, opts.ThreadLimit)
+			if err != nil {
+				return nil, fmt.Errorf("export threads of %s: %w", a.ID, err)
+			}
+			for _, t := range threads {
+				messages, err := c.ListAllMessages(ctx, t.ID)
+				if err != nil {
+					return nil, fmt.Errorf("export thread %s: %w", t.ID, err)
+				}
+				entry.Threads = append(entry.Threads, BundledThread{Thread: t, Messages: messages})
+			}
+		}
+		bundle.Assistants = append(bundle.Assistants, entry)
+	}
+
+	return bundle, nil
+}
+
+// ReadAssistantBundle decodes a bundle and checks its version.
+func ReadAssistantBundle(r io.Reader) (*AssistantBundle, error) {
+	var bundle AssistantBundle
+	if err := json.NewDecoder(r).Decode(&bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle: %w", err)
+	}
+	if bundle.Version < 1 || bundle.Version > AssistantBundleVersion {
+		return nil, fmt.Errorf("unsupported bundle version %d (max %d)", bundle.Version, AssistantBundleVersion)
+	}
+	return &bundle, nil
+}
+
+// Write encodes the bundle as indented JSON.
+func (b *AssistantBundle) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(b)
+}
+
+// ImportAssistants creates the bundled assistants in this account, matching
+// existing assistants by name and resolving conflicts with opts.OnConflict.
+// The bundled threads of skipped assistants are not imported. An unknown
+// policy is rejected before any API call.
+func (c *Client) ImportAssistants(ctx context.Context, bundle *AssistantBundle, opts ImportOptions) (*ImportResult, error) {
+	if bundle.Version < 1 || bundle.Version > AssistantBundleVersion {
+		return nil, fmt.Errorf("unsupported bundle version %d (max %d)", bundle.Version, AssistantBundleVersion)
+	}
+	switch opts.OnConflict {
+	case "", ConflictSkip, ConflictOverwrite, ConflictRename:
+	default:
+		return nil, fmt.Errorf("unknown conflict policy %q", opts.OnConflict)
+	}
+
+	existing, err := c.ListAssistants(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]Assistant, len(existing))
+	for _, a := range existing {
+		byName[a.Name] = a
+	}
+
+	result := &ImportResult{
+		AssistantIDs: make(map[string]string),
+		ThreadIDs:    make(map[string]string),
+	}
+	for _, entry := range bundle.Assistants {
+		src := entry.Assistant
+		var dst *Assistant
+
+		current, conflict := byName[src.Name]
+		switch {
+		case !conflict:
+			dst, err = c.CreateAssistantWithRequest(ctx, assistantCreateRequest(src, src.Name))
+			if err == nil {
+				result.Created = append(result.Created, *dst)
+			}
+		case opts.OnConflict == ConflictOverwrite:
+			dst, err = c.ModifyAssistant(ctx, current.ID, assistantOverwriteRequest(src))
+			if err == nil {
+				result.Updated = append(result.Updated, *dst)
+			}
+		case opts.OnConflict == ConflictRename:
+			name := uniqueName(src.Name, byName)
+			dst, err = c.CreateAssistantWithRequest(ctx, copyCreateRequest(src, name))
+			if err == nil {
+				result.Created = append(result.Created, *dst)
+			}
+		default: // ConflictSkip
+			result.Skipped = append(result.Skipped, current)
+			result.AssistantIDs[src.ID] = current.ID
+			continue
+		}
+		if err != nil {
+			return result, fmt.Errorf("import assistant %q: %w", src.Name, err)
+		}
+		byName[dst.Name] = *dst
+		result.AssistantIDs[src.ID] = dst.ID
+
+		if opts.ImportThreads {
+			for _, bt := range entry.Threads {
+				thread, err := c.importThread(ctx, bt)
+				if err != nil {
+					return result, fmt.Errorf("import thread %s: %w", bt.Thread.ID, err)
+				}
+				result.ThreadIDs[bt.Thread.ID] = thread.ID
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// CloneAssistant copies an assistant within the same account.
+// An empty name defaults to "<original name> (copy)".
+func (c *Client) CloneAssistant(ctx context.Context, assistantID, name string) (*Assistant, error) {
+	src, err := c.GetAssistant(ctx, assistantID)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = src.Name + " (copy)"
+	}
+	return c.CreateAssistantWithRequest(ctx, copyCreateRequest(*src, name))
+}
+
+// importThread recreates a bundled thread, recording its source ID.
+func (c *Client) importThread(ctx context.Context, bt BundledThread) (*Thread, error) {
+	metadata := make(map[string]string, len(bt.Thread.Metadata)+1)
+	for k, v := range bt.Thread.Metadata {
+		metadata[k] = v
+	}
+	metadata["source_thread_id"] = bt.Thread.ID
+
+	thread, err := c.CreateThreadWithMetadata(ctx, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := CopyMessages(ctx, c, thread.ID, bt.Messages, 0); err != nil {
+		return nil, err
+	}
+	return thread, nil
+}
+
+// assistantCreateRequest copies an assistant's settings into a create request.
+func assistantCreateRequest(a Assistant, name string) AssistantCreateRequest {
+	return AssistantCreateRequest{
+		Name:           name,
+		Instructions:   a.Instructions,
+		Description:    a.Description,
+		Model:          a.Model,
+		Temperature:    a.Temperature,
+		EnableThinking: a.EnableThinking,
+		ToolsConfig:    a.ToolsConfig,
+		Metadata:       a.Metadata,
+	}
+}
+
+// copyCreateRequest is assistantCreateRequest for a second copy of an
+// assistant. The assistantsync key is dropped so the copy is not mistaken
+// for the managed original.
+func copyCreateRequest(a Assistant, name string) AssistantCreateRequest {
+	req := assistantCreateRequest(a, name)
+	if _, ok := a.Metadata[SyncMetadataKey]; ok {
+		req.Metadata = make(map[string]string, len(a.Metadata))
+		for k, v := range a.Metadata {
+			if k != SyncMetadataKey {
+				req.Metadata[k] = v
+			}
+		}
+	}
+	return req
+}
+
+// assistantOverwriteRequest replaces every setting of an assistant.
+func assistantOverwriteRequest(a Assistant) AssistantUpdateRequest {
+	metadata := a.Metadata
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	req := AssistantUpdateRequest{
+		Name:             &a.Name,
+		Instructions:     &a.Instructions,
+		Description:      &a.Description,
+		Temperature:      a.Temperature,
+		EnableThinking:   a.EnableThinking,
+		ToolsConfig:      a.ToolsConfig,
+		ResetToolsConfig: a.ToolsConfig == nil,
+		Metadata:         metadata,
+	}
+	if a.Model != "" {
+		req.Model = &a.Model // Keep the destination model when unset
+	}
+	return req
+}
+
+// uniqueName returns name, or "name (N)" with the lowest free N >= 2.
+func uniqueName(name string, taken map[string]Assistant) string {
+	if _, ok := taken[name]; !ok {
+		return name
+	}
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s (%d)", name, n)
+		if _, ok := taken[candidate]; !ok {
+			return candidate
+		}
+	}
+}
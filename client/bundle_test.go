@@ -0,0 +1,178 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeAccount is a minimal in-memory PixiGPT server for assistants/threads.
+type fakeAccount struct {
+	mu         sync.Mutex
+	assistants []map[string]interface{}
+	threads    map[string]map[string]string
+	messages   map[string][]BulkMessage
+	requests   []string
+}
+
+func newFakeAccount(t *testing.T) (*fakeAccount, *Client) {
+	f := &fakeAccount{threads: map[string]map[string]string{}, messages: map[string][]BulkMessage{}}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, New("test-key", srv.URL)
+}
+
+func (f *fakeAccount) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var body map[string]interface{}
+	raw, _ := io.ReadAll(r.Body)
+	json.Unmarshal(raw, &body)
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.Method == "GET" && r.URL.Path == "/assistants":
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": f.assistants})
+	case r.Method == "POST" && r.URL.Path == "/assistants":
+		body["id"] = fmt.Sprintf("asst_%d", len(f.assistants)+1)
+		f.assistants = append(f.assistants, body)
+		json.NewEncoder(w).Encode(body)
+	case r.Method == "PUT" && path[0] == "assistants":
+		for _, a := range f.assistants {
+			if a["id"] == path[1] {
+				for k, v := range body {
+					a[k] = v
+				}
+				json.NewEncoder(w).Encode(a)
+				return
+			}
+		}
+		w.WriteHeader(404)
+	case r.Method == "GET" && path[0] == "assistants" && len(path) == 2:
+		for _, a := range f.assistants {
+			if a["id"] == path[1] {
+				json.NewEncoder(w).Encode(a)
+				return
+			}
+		}
+		w.WriteHeader(404)
+	case r.Method == "POST" && r.URL.Path == "/threads":
+		id := fmt.Sprintf("thread_%d", len(f.threads)+1)
+		md := map[string]string{}
+		if m, ok := body["metadata"].(map[string]interface{}); ok {
+			for k, v := range m {
+				md[k] = v.(string)
+			}
+		}
+		f.threads[id] = md
+		json.NewEncoder(w).Encode(Thread{ID: id, Object: "thread", Metadata: md})
+	case r.Method == "POST" && len(path) == 4 && path[3] == "bulk":
+		var req struct {
+			Messages []BulkMessage `json:"messages"`
+		}
+		json.Unmarshal(raw, &req)
+		f.messages[path[1]] = append(f.messages[path[1]], req.Messages...)
+		io.WriteString(w, `{"object":"list","data":[]}`)
+	default:
+		w.WriteHeader(404)
+	}
+}
+
+func TestImportAssistantsConflictPolicies(t *testing.T) {
+	bundle := &AssistantBundle{
+		Version: AssistantBundleVersion,
+		Assistants: []BundledAssistant{{
+			Assistant: Assistant{ID: "src_1", Name: "Support", Instructions: "v2", Model: "pixi-large"},
+			Threads: []BundledThread{{
+				Thread: Thread{ID: "src_thread", Metadata: map[string]string{"user": "42"}},
+				Messages: []ThreadMessage{
+					{Role: "user", Content: []MessageContent{{Type: "text", Text: MessageContentText{Value: "Hi"}}}},
+					{Role: "assistant", Content: []MessageContent{{Type: "text", Text: MessageContentText{Value: "Hello!"}}}},
+				},
+			}},
+		}},
+	}
+
+	// Round trip through the serialised form
+	var buf bytes.Buffer
+	if err := bundle.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	bundle, err := ReadAssistantBundle(&buf)
+	if err != nil {
+		t.Fatalf("ReadAssistantBundle failed: %v", err)
+	}
+
+	f, c := newFakeAccount(t)
+	f.assistants = []map[string]interface{}{{"id": "asst_0", "name": "Support", "instructions": "v1"}}
+	ctx := context.Background()
+
+	res, err := c.ImportAssistants(ctx, bundle, ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import (skip) failed: %v", err)
+	}
+	if len(res.Skipped) != 1 || res.AssistantIDs["src_1"] != "asst_0" {
+		t.Errorf("Expected skip, got %+v", res)
+	}
+
+	res, err = c.ImportAssistants(ctx, bundle, ImportOptions{OnConflict: ConflictRename, ImportThreads: true})
+	if err != nil {
+		t.Fatalf("Import (rename) failed: %v", err)
+	}
+	if len(res.Created) != 1 || res.Created[0].Name != "Support (2)" {
+		t.Fatalf("Expected renamed copy, got %+v", res.Created)
+	}
+	newThread := res.ThreadIDs["src_thread"]
+	if f.threads[newThread]["source_thread_id"] != "src_thread" || f.threads[newThread]["user"] != "42" {
+		t.Errorf("Unexpected thread metadata: %v", f.threads[newThread])
+	}
+	if msgs := f.messages[newThread]; len(msgs) != 2 || msgs[1].Content != "Hello!" {
+		t.Errorf("Unexpected imported messages: %+v", msgs)
+	}
+
+	res, err = c.ImportAssistants(ctx, bundle, ImportOptions{OnConflict: ConflictOverwrite})
+	if err != nil {
+		t.Fatalf("Import (overwrite) failed: %v", err)
+	}
+	if len(res.Updated) != 1 || res.Updated[0].ID != "asst_0" || res.Updated[0].Instructions != "v2" {
+		t.Errorf("Expected overwrite of asst_0, got %+v", res.Updated)
+	}
+}
+
+func TestImportAssistantsRejectsUnknownPolicy(t *testing.T) {
+	c := New("test-key", "http://127.0.0.1:9", WithRetryMax(0)) // Any request would fail differently
+	bundle := &AssistantBundle{Version: AssistantBundleVersion}
+
+	_, err := c.ImportAssistants(context.Background(), bundle, ImportOptions{OnConflict: "Overwrite"})
+	if err == nil || err.Error() != `unknown conflict policy "Overwrite"` {
+		t.Errorf("Expected unknown policy error, got %v", err)
+	}
+}
+
+func TestCloneAssistant(t *testing.T) {
+	f, c := newFakeAccount(t)
+	f.assistants = []map[string]interface{}{{"id": "asst_0", "name": "Support", "instructions": "Be nice.", "metadata": map[string]interface{}{"team": "cx"}}}
+
+	clone, err := c.CloneAssistant(context.Background(), "asst_0", "")
+	if err != nil {
+		t.Fatalf("CloneAssistant failed: %v", err)
+	}
+	if clone.ID == "asst_0" || clone.Name != "Support (copy)" || clone.Instructions != "Be nice." || clone.Metadata["team"] != "cx" {
+		t.Errorf("Unexpected clone: %+v", clone)
+	}
+}
+
+func TestReadAssistantBundleVersion(t *testing.T) {
+	if _, err := ReadAssistantBundle(strings.NewReader(`{"version":99}`)); err == nil {
+		t.Error("Expected error for unsupported version")
+	}
+}
@@ -11,7 +11,9 @@ import (
 // The client manages conversation history.
 //
 // Chain of thought reasoning is returned in the ReasoningContent field
-// when enable_thinking is true (default).
+// when enable_thinking is true (default). Inline <think> tags left in the
+// content are split out client-side. Reasoning in the request history is
+// stripped unless req.KeepReasoning is set.
 //
 // Example:
 //
@@ -23,14 +25,16 @@ import (
 //	    Temperature: 0.7,
 //	    MaxTokens: 2000,
 //	})
-//	if resp.Choices[0].ReasoningContent != "" {
-//	    fmt.Printf("Reasoning: %s\n", resp.Choices[0].ReasoningContent)
+//	if reasoning, _ := resp.Reasoning(); reasoning != "" {
+//	    fmt.Printf("Reasoning: %s\n", reasoning)
 //	}
 func (c *Client) CreateChatCompletion(ctx context.Context, req ChatCompletionRequest) (*ChatCompletionResponse, error) {
 	// Note: Server defaults temperature to 0.6 if 0
 	// Note: Server omits max_tokens if 0 (lets vLLM handle it)
 	// No client-side defaults needed - pass values as-is
 
+	req.Messages = replayMessages(req.Messages, req.KeepReasoning)
+
 	body, err := json.Marshal(req)
 	if err != nil {
 		return nil, err
@@ -41,6 +45,40 @@ func (c *Client) CreateChatCompletion(ctx context.Context, req ChatCompletionReq
 		return nil, err
 	}
 
-	// Server now returns reasoning_content directly - no parsing needed
+	// Server usually returns reasoning_content directly; fall back to
+	// splitting inline <think> tags for paths that don't
+	for i := range resp.Choices {
+		splitChoiceThinking(&resp.Choices[i])
+	}
 	return &resp, nil
 }
+
+// FirstChoice returns the first choice, or ErrNoChoices.
+func (r *ChatCompletionResponse) FirstChoice() (*ChatCompletionChoice, error) {
+	if r == nil || len(r.Choices) == 0 {
+		return nil, ErrNoChoices
+	}
+	return &r.Choices[0], nil
+}
+
+// Content returns the first choice's message content.
+func (r *ChatCompletionResponse) Content() (string, error) {
+	choice, err := r.FirstChoice()
+	if err != nil {
+		return "", err
+	}
+	return choice.Message.Content, nil
+}
+
+// Reasoning returns the first choice's chain of thought, empty when
+// thinking was disabled.
+func (r *ChatCompletionResponse) Reasoning() (string, error) {
+	choice, err := r.FirstChoice()
+	if err != nil {
+		return "", err
+	}
+	if choice.ReasoningContent != "" {
+		return choice.ReasoningContent, nil
+	}
+	return choice.Message.ReasoningContent, nil
+}
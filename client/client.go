@@ -157,3 +157,41 @@ func (c *Client) doRequest(ctx context.Context, method, path string, bodyBytes [
 
 	return fmt.Errorf("max retries exceeded: %w", lastErr)
 }
+
+// doStream sends a request that answers with a server-sent event stream.
+//
+// Streams are not retried and ignore the client's overall timeout - ctx
+// controls their lifetime. The caller must close the returned body.
+func (c *Client) doStream(ctx context.Context, method, path string, bodyBytes []byte) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+
+	// Long-lived stream - drop the overall timeout, keep transport settings
+	streamClient := *c.httpClient
+	streamClient.Timeout = 0
+
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		var apiErr APIError
+		if err := json.Unmarshal(respBody, &apiErr); err != nil {
+			return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(respBody))
+		}
+		apiErr.StatusCode = resp.StatusCode
+		return nil, &apiErr
+	}
+
+	return resp.Body, nil
+}
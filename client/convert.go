@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// ConvertOptions configures ThreadFromMessages and MessagesFromThread.
+type ConvertOptions struct {
+	// KeepReasoning copies chain of thought (ReasoningContent) across.
+	KeepReasoning bool
+	// BatchSize is the number of messages per bulk request (default 100).
+	BatchSize int
+	// Metadata is set on the thread created by ThreadFromMessages.
+	Metadata map[string]string
+}
+
+// ThreadFromMessages creates a new thread holding a stateless chat
+// conversation, in order. Tool calls and tool results are kept, so the
+// thread can be continued with runs.
+//
+// Example (promote a chat completion conversation to a thread):
+//
+//	messages = append(messages, resp.Choices[0].Message)
+//	thread, err := client.ThreadFromMessages(ctx, messages, ConvertOptions{})
+//	run, err := client.CreateRunAndWait(ctx, thread.ID, assistantID, true)
+func (c *Client) ThreadFromMessages(ctx context.Context, messages []Message, opts ConvertOptions) (*Thread, error) {
+	bulk := make([]BulkMessage, 0, len(messages))
+	for i, m := range messages {
+		if m.Role == "" {
+			return nil, fmt.Errorf("message %d: role is required", i)
+		}
+		b := BulkMessage{
+			Role:       m.Role,
+			Content:    m.Content,
+			ToolCalls:  m.ToolCalls,
+			ToolCallID: m.ToolCallID,
+		}
+		if opts.KeepReasoning {
+			b.ReasoningContent = m.ReasoningContent
+		}
+		bulk = append(bulk, b)
+	}
+
+	thread, err := c.CreateThreadWithMetadata(ctx, opts.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	if err := createMessagesBatched(ctx, c, thread.ID, bulk, opts.BatchSize); err != nil {
+		return thread, err
+	}
+	return thread, nil
+}
+
+// MessagesFromThread converts thread messages (as returned by
+// ListAllMessages) into chat completion messages, e.g. to replay a thread
+// through CreateChatCompletion for offline evaluation.
+//
+// Text parts are joined with newlines; tool calls and tool call IDs are
+// kept. Attachments (sources, media, code) have no chat equivalent and are
+// dropped.
+func MessagesFromThread(messages []ThreadMessage, opts ConvertOptions) []Message {
+	out := make([]Message, 0, len(messages))
+	for _, m := range messages {
+		msg := Message{
+			Role:       m.Role,
+			Content:    m.Text(),
+			ToolCalls:  m.ToolCalls,
+			ToolCallID: m.ToolCallID,
+		}
+		if opts.KeepReasoning {
+			msg.ReasoningContent = m.ReasoningContent
+		}
+		out = append(out, msg)
+	}
+	return out
+}
+
+// bulkMessage copies a thread message into a bulk create request,
+// keeping tool calls and (optionally) reasoning.
+func bulkMessage(m ThreadMessage, keepReasoning bool) BulkMessage {
+	b := BulkMessage{
+		Role:       m.Role,
+		Content:    m.Text(),
+		ToolCalls:  m.ToolCalls,
+		ToolCallID: m.ToolCallID,
+	}
+	if keepReasoning {
+		b.ReasoningContent = m.ReasoningContent
+	}
+	return b
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func convertFixture() []Message {
+	callID := "call_1"
+	return []Message{
+		{Role: "system", Content: "You are terse."},
+		{Role: "user", Content: "Weather in Paris?"},
+		{Role: "assistant", ReasoningContent: "Need the tool.", ToolCalls: []ToolCall{{
+			ID: callID, Type: "function",
+			Function: ToolCallFunction{Name: "get_weather", Arguments: `{"city":"Paris"}`},
+		}}},
+		{Role: "tool", Content: `{"temp_c":21}`, ToolCallID: &callID},
+		{Role: "assistant", Content: "21°C."},
+	}
+}
+
+func TestThreadFromMessages(t *testing.T) {
+	var metadata map[string]string
+	var bulk []BulkMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/threads":
+			var body struct {
+				Metadata map[string]string `json:"metadata"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			metadata = body.Metadata
+			io.WriteString(w, `{"id":"thread_1"}`)
+		case "/threads/thread_1/messages/bulk":
+			var body struct {
+				Messages []BulkMessage `json:"messages"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			bulk = append(bulk, body.Messages...)
+			io.WriteString(w, `{"data":[]}`)
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer srv.Close()
+	c := New("test-key", srv.URL, WithRetryMax(0))
+
+	msgs := convertFixture()
+	thread, err := c.ThreadFromMessages(context.Background(), msgs, ConvertOptions{
+		Metadata: map[string]string{"origin": "chat"},
+	})
+	if err != nil {
+		t.Fatalf("ThreadFromMessages failed: %v", err)
+	}
+	if thread.ID != "thread_1" || metadata["origin"] != "chat" {
+		t.Errorf("Unexpected thread %+v / metadata %v", thread, metadata)
+	}
+	if len(bulk) != len(msgs) {
+		t.Fatalf("Expected %d messages, got %d", len(msgs), len(bulk))
+	}
+	if !reflect.DeepEqual(bulk[2].ToolCalls, msgs[2].ToolCalls) {
+		t.Errorf("Tool calls not kept: %+v", bulk[2].ToolCalls)
+	}
+	if bulk[2].ReasoningContent != "" {
+		t.Errorf("Reasoning should be dropped without KeepReasoning")
+	}
+	if bulk[3].ToolCallID == nil || *bulk[3].ToolCallID != "call_1" {
+		t.Errorf("Tool call ID not kept: %+v", bulk[3])
+	}
+}
+
+func TestMessagesFromThread(t *testing.T) {
+	callID := "call_1"
+	thread := []ThreadMessage{
+		{Role: "user", Content: []MessageContent{
+			{Type: "text", Text: MessageContentText{Value: "line one"}},
+			{Type: "image_file"},
+			{Type: "text", Text: MessageContentText{Value: "line two"}},
+		}},
+		{Role: "assistant", ReasoningContent: "hmm", ToolCalls: []ToolCall{{ID: callID, Type: "function"}}},
+		{Role: "tool", ToolCallID: &callID, Content: []MessageContent{{Type: "text", Text: MessageContentText{Value: "ok"}}}},
+	}
+
+	got := MessagesFromThread(thread, ConvertOptions{KeepReasoning: true})
+	if len(got) != 3 {
+		t.Fatalf("Expected 3 messages, got %d", len(got))
+	}
+	if got[0].Content != "line one\nline two" {
+		t.Errorf("Unexpected flattened content %q", got[0].Content)
+	}
+	if got[1].ReasoningContent != "hmm" || len(got[1].ToolCalls) != 1 {
+		t.Errorf("Unexpected assistant message %+v", got[1])
+	}
+	if got[2].ToolCallID == nil || *got[2].ToolCallID != callID || got[2].Content != "ok" {
+		t.Errorf("Unexpected tool message %+v", got[2])
+	}
+
+	if MessagesFromThread(thread, ConvertOptions{})[1].ReasoningContent != "" {
+		t.Error("Reasoning should be dropped without KeepReasoning")
+	}
+}
@@ -0,0 +1,224 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ToolHandler executes a client-side function tool.
+//
+// arguments is the JSON string produced by the model. The returned string is
+// submitted as the tool output; a returned error is reported to the model as
+// {"error": "..."} so it can recover.
+type ToolHandler func(ctx context.Context, arguments string) (string, error)
+
+// RunDriver runs a thread to completion, executing client-side function
+// tools whenever the run enters the "requires_action" status.
+//
+// Example:
+//
+//	driver := client.NewRunDriver()
+//	driver.RegisterTool(weatherTool, func(ctx context.Context, args string) (string, error) {
+//	    return `{"temp_c": 21}`, nil
+//	})
+//	run, err := driver.Run(ctx, threadID, RunRequest{AssistantID: assistantID})
+type RunDriver struct {
+	client   *Client
+	handlers map[string]ToolHandler
+	tools    []Tool
+
+	// Stream drives the run over CreateRunStream instead of polling.
+	Stream bool
+	// OnEvent receives every stream event when Stream is set.
+	OnEvent func(RunEvent) error
+	// WaitOptions configure polling when Stream is not set.
+	WaitOptions []WaitOption
+	// MaxRounds limits tool submission rounds per run (default 10).
+	MaxRounds int
+}
+
+// NewRunDriver creates a RunDriver with no registered tools.
+func (c *Client) NewRunDriver() *RunDriver {
+	return &RunDriver{
+		client:    c,
+		handlers:  make(map[string]ToolHandler),
+		MaxRounds: 10,
+	}
+}
+
+// Register sets the handler for calls to the named function.
+//
+// Use this for functions already configured on the assistant.
+func (d *RunDriver) Register(name string, handler ToolHandler) {
+	d.handlers[name] = handler
+}
+
+// RegisterTool sets the handler for a function tool and sends the tool
+// definition with every run started by the driver.
+func (d *RunDriver) RegisterTool(tool Tool, handler ToolHandler) error {
+	name := toolName(tool)
+	if name == "" {
+		return fmt.Errorf("tool definition has no function name")
+	}
+	d.tools = append(d.tools, tool)
+	d.handlers[name] = handler
+	return nil
+}
+
+// Run starts a run on the thread and drives it until it finishes,
+// dispatching tool calls to the registered handlers.
+//
+// Returns the final run; when streaming, run.Message holds the accumulated
+// assistant message.
+func (d *RunDriver) Run(ctx context.Context, threadID string, req RunRequest) (*Run, error) {
+	req.Tools = append(append([]Tool(nil), req.Tools...), d.tools...)
+	if d.Stream {
+		return d.runStream(ctx, threadID, req)
+	}
+	return d.runPoll(ctx, threadID, req)
+}
+
+// runPoll drives the run with WaitForRun and SubmitToolOutputs.
+func (d *RunDriver) runPoll(ctx context.Context, threadID string, req RunRequest) (*Run, error) {
+	run, err := d.client.CreateRunWithRequest(ctx, threadID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	for round := 0; ; round++ {
+		run, err = d.client.WaitForRun(ctx, threadID, run.ID, d.WaitOptions...)
+		if err != nil || run.Status != "requires_action" {
+			return run, err
+		}
+		if round >= d.maxRounds() {
+			return run, fmt.Errorf("run %s exceeded %d tool rounds", run.ID, d.maxRounds())
+		}
+
+		outputs, err := d.dispatch(ctx, run)
+		if err != nil {
+			return run, err
+		}
+		if run, err = d.client.SubmitToolOutputs(ctx, threadID, run.ID, outputs); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// runStream drives the run with CreateRunStream and SubmitToolOutputsStream.
+func (d *RunDriver) runStream(ctx context.Context, threadID string, req RunRequest) (*Run, error) {
+	stream, err := d.client.CreateRunStream(ctx, threadID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	for round := 0; ; round++ {
+		run, err := d.consume(stream)
+		stream.Close()
+		if err != nil {
+			return run, err
+		}
+		// Same outcomes as WaitForRun, plus a dropped connection
+		if run == nil || !runStopped(run.Status) {
+			return run, ErrStreamEnded
+		}
+		if err := runStatusError(run.Status); err != nil || run.Status != "requires_action" {
+			return run, err
+		}
+
+		if round >= d.maxRounds() {
+			return run, fmt.Errorf("run %s exceeded %d tool rounds", run.ID, d.maxRounds())
+		}
+		outputs, err := d.dispatch(ctx, run)
+		if err != nil {
+			return run, err
+		}
+		if stream, err = d.client.SubmitToolOutputsStream(ctx, threadID, run.ID, outputs); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// consume reads one stream to the end and returns the last run state, with
+// the accumulated message attached.
+func (d *RunDriver) consume(stream *RunStream) (*Run, error) {
+	var acc MessageAccumulator
+	for {
+		ev, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return acc.Run(), err
+		}
+
+		acc.Add(ev)
+		if d.OnEvent != nil {
+			if err := d.OnEvent(ev); err != nil {
+				return acc.Run(), err
+			}
+		}
+		if e, ok := ev.(*ErrorEvent); ok {
+			return acc.Run(), &e.APIError
+		}
+	}
+
+	run := acc.Run()
+	if run != nil && run.Message == nil {
+		run.Message = acc.Message()
+	}
+	return run, nil
+}
+
+// dispatch runs the handlers for every pending tool call.
+func (d *RunDriver) dispatch(ctx context.Context, run *Run) ([]ToolOutput, error) {
+	if run.RequiredAction == nil || run.RequiredAction.Type != "submit_tool_outputs" {
+		return nil, fmt.Errorf("run %s requires unsupported action", run.ID)
+	}
+
+	calls := run.RequiredAction.SubmitToolOutputs.ToolCalls
+	outputs := make([]ToolOutput, 0, len(calls))
+	for _, call := range calls {
+		var output string
+		handler, ok := d.handlers[call.Function.Name]
+		if !ok {
+			output = toolError(fmt.Errorf("unknown tool: %s", call.Function.Name))
+		} else {
+			result, err := handler(ctx, call.Function.Arguments)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				result = toolError(err)
+			}
+			output = result
+		}
+		outputs = append(outputs, ToolOutput{ToolCallID: call.ID, Output: output})
+	}
+	return outputs, nil
+}
+
+func (d *RunDriver) maxRounds() int {
+	if d.MaxRounds <= 0 {
+		return 10
+	}
+	return d.MaxRounds
+}
+
+// toolError formats a handler error as a tool output for the model.
+func toolError(err error) string {
+	out, _ := json.Marshal(map[string]string{"error": err.Error()})
+	return string(out)
+}
+
+// toolName extracts function.name from an OpenAI-format tool definition.
+func toolName(tool Tool) string {
+	fn, ok := tool["function"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	name, _ := fn["name"].(string)
+	return name
+}
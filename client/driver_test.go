@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var testWeatherTool = Tool{
+	"type": "function",
+	"function": map[string]interface{}{
+		"name":       "get_weather",
+		"parameters": map[string]interface{}{"type": "object"},
+	},
+}
+
+func TestRunDriverPolling(t *testing.T) {
+	var mu sync.Mutex
+	var submitted []ToolOutput
+	var sentTools int
+	status := "requires_action"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/runs"):
+			var req RunRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			sentTools = len(req.Tools)
+			io.WriteString(w, `{"id":"run_1","status":"queued"}`)
+		case strings.HasSuffix(r.URL.Path, "/submit_tool_outputs"):
+			var body struct {
+				ToolOutputs []ToolOutput `json:"tool_outputs"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			submitted = body.ToolOutputs
+			status = "completed"
+			io.WriteString(w, `{"id":"run_1","status":"in_progress"}`)
+		case status == "requires_action":
+			io.WriteString(w, `{"id":"run_1","status":"requires_action","required_action":{"type":"submit_tool_outputs","submit_tool_outputs":{"tool_calls":[
+				{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"city\":\"Paris\"}"}},
+				{"id":"call_2","type":"function","function":{"name":"get_time","arguments":"{}"}}]}}}`)
+		default:
+			io.WriteString(w, `{"id":"run_1","status":"completed"}`)
+		}
+	}))
+	defer srv.Close()
+
+	c := New("test-key", srv.URL)
+	driver := c.NewRunDriver()
+	driver.WaitOptions = []WaitOption{WithPollInterval(10*time.Millisecond, 10*time.Millisecond)}
+	var gotArgs string
+	if err := driver.RegisterTool(testWeatherTool, func(ctx context.Context, args string) (string, error) {
+		gotArgs = args
+		return `{"temp_c":21}`, nil
+	}); err != nil {
+		t.Fatalf("RegisterTool failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	run, err := driver.Run(ctx, "thread_1", RunRequest{AssistantID: "asst_1"})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if run.Status != "completed" {
+		t.Errorf("Expected status 'completed', got %s", run.Status)
+	}
+	if sentTools != 1 {
+		t.Errorf("Expected 1 tool definition in run request, got %d", sentTools)
+	}
+	if gotArgs != `{"city":"Paris"}` {
+		t.Errorf("Unexpected handler arguments: %s", gotArgs)
+	}
+	if len(submitted) != 2 {
+		t.Fatalf("Expected 2 tool outputs, got %d", len(submitted))
+	}
+	if submitted[0].ToolCallID != "call_1" || submitted[0].Output != `{"temp_c":21}` {
+		t.Errorf("Unexpected output: %+v", submitted[0])
+	}
+	if !strings.Contains(submitted[1].Output, "unknown tool") {
+		t.Errorf("Expected unknown tool error output, got %s", submitted[1].Output)
+	}
+}
+
+func TestRunDriverStreaming(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/submit_tool_outputs") {
+			io.WriteString(w, "event: thread.message.delta\ndata: {\"id\":\"msg_1\",\"delta\":{\"content\":[{\"index\":0,\"type\":\"text\",\"text\":{\"value\":\"21C\"}}]}}\n\n"+
+				"event: thread.run.completed\ndata: {\"id\":\"run_1\",\"status\":\"completed\"}\n\n")
+			return
+		}
+		io.WriteString(w, "event: thread.run.requires_action\ndata: {\"id\":\"run_1\",\"status\":\"requires_action\",\"required_action\":{\"type\":\"submit_tool_outputs\",\"submit_tool_outputs\":{\"tool_calls\":[{\"id\":\"call_1\",\"type\":\"function\",\"function\":{\"name\":\"get_weather\",\"arguments\":\"{}\"}}]}}}\n\n")
+	}))
+	defer srv.Close()
+
+	c := New("test-key", srv.URL)
+	driver := c.NewRunDriver()
+	driver.Stream = true
+	driver.Register("get_weather", func(ctx context.Context, args string) (string, error) {
+		return "", errors.New("sensor offline")
+	})
+
+	run, err := driver.Run(context.Background(), "thread_1", RunRequest{AssistantID: "asst_1"})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if run.Status != "completed" {
+		t.Errorf("Expected status 'completed', got %s", run.Status)
+	}
+	if run.Message == nil || run.Message.Content[0].Text.Value != "21C" {
+		t.Errorf("Unexpected message: %+v", run.Message)
+	}
+}
+
+func TestRunDriverStreamingUnfinishedRun(t *testing.T) {
+	for body, want := range map[string]string{
+		"event: thread.run.expired\ndata: {\"id\":\"run_1\",\"status\":\"expired\"}\n\n":         "run expired",
+		"event: thread.run.in_progress\ndata: {\"id\":\"run_1\",\"status\":\"in_progress\"}\n\n": ErrStreamEnded.Error(),
+	} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, body)
+		}))
+		driver := New("test-key", srv.URL).NewRunDriver()
+		driver.Stream = true
+
+		run, err := driver.Run(context.Background(), "thread_1", RunRequest{AssistantID: "asst_1"})
+		srv.Close()
+		if err == nil || err.Error() != want || run == nil {
+			t.Errorf("Expected %q with the last run, got %v (%+v)", want, err, run)
+		}
+	}
+}
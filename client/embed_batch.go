@@ -0,0 +1,232 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EmbedOptions configures EmbedAll.
+type EmbedOptions struct {
+	BatchSize      int // Max inputs per request (default 64)
+	MaxBatchTokens int // Max estimated tokens per request (default 8000)
+	Concurrency    int // Parallel requests (default 4)
+	MaxRetries     int // Retries per failed batch (default 3, -1 = none)
+	// EncodingFormat, Dimensions and User are passed to every request.
+	// EncodingBase64 is recommended for large jobs.
+	EncodingFormat string
+	Dimensions     int
+	User           string
+	// OnProgress is called after each batch with the number of embedded
+	// inputs so far. Calls are serialized.
+	OnProgress func(done, total int)
+}
+
+// EmbedResult holds the vectors from EmbedAll, in input order.
+type EmbedResult struct {
+	Vectors [][]float32
+	Usage   EmbeddingUsage // Summed over all batches
+}
+
+// EstimateTokens roughly estimates the token count of text for batching
+// without a tokenizer: four bytes or 0.75 words per token, whichever is
+// larger.
+func EstimateTokens(text string) int {
+	if text == "" {
+		return 0
+	}
+	byBytes := (len(text) + 3) / 4
+	byWords := (len(strings.Fields(text))*4 + 2) / 3
+	return max(byBytes, byWords, 1)
+}
+
+// EmbedAll embeds any number of texts.
+//
+// Inputs are split into batches by count and estimated tokens, sent by a
+// bounded pool of workers, and failed batches are retried with exponential
+// backoff. The first batch that still fails cancels the rest.
+//
+// Example:
+//
+//	res, err := client.EmbedAll(ctx, docs, EmbedOptions{
+//	    Concurrency: 8,
+//	    OnProgress:  func(done, total int) { log.Printf("%d/%d", done, total) },
+//	})
+//	// res.Vectors[i] is the embedding of docs[i]
+func (c *Client) EmbedAll(ctx context.Context, texts []string, opts EmbedOptions) (*EmbedResult, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 64
+	}
+	if opts.MaxBatchTokens <= 0 {
+		opts.MaxBatchTokens = 8000
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 4
+	}
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = 3
+	}
+
+	probe := EmbeddingRequest{Input: "", EncodingFormat: opts.EncodingFormat, Dimensions: opts.Dimensions}
+	if err := probe.Validate(); err != nil {
+		return nil, err
+	}
+
+	result := &EmbedResult{Vectors: make([][]float32, len(texts))}
+	batches := embedBatches(texts, opts.BatchSize, opts.MaxBatchTokens)
+	if len(batches) == 0 {
+		return result, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+		done     int
+		wg       sync.WaitGroup
+	)
+	jobs := make(chan embedBatch)
+
+	for w := 0; w < min(opts.Concurrency, len(batches)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := range jobs {
+				req := NewBatchEmbeddingRequest(texts[b.start:b.end])
+				req.EncodingFormat = opts.EncodingFormat
+				req.Dimensions = opts.Dimensions
+				req.User = opts.User
+				resp, err := c.embedBatchWithRetry(ctx, req, b.end-b.start, opts.MaxRetries)
+
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = fmt.Errorf("embed inputs %d-%d: %w", b.start, b.end-1, err)
+						cancel()
+					}
+					mu.Unlock()
+					continue
+				}
+				for _, d := range resp.Data {
+					result.Vectors[b.start+d.Index] = d.Embedding
+				}
+				result.Usage.PromptTokens += resp.Usage.PromptTokens
+				result.Usage.TotalTokens += resp.Usage.TotalTokens
+				done += b.end - b.start
+				if opts.OnProgress != nil {
+					opts.OnProgress(done, len(texts))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for _, b := range batches {
+		select {
+		case jobs <- b:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// embedBatch is a half-open range of inputs sent in one request.
+type embedBatch struct {
+	start, end int
+}
+
+// embedBatches greedily groups inputs by count and estimated tokens. An
+// input above maxTokens on its own gets a batch to itself.
+func embedBatches(texts []string, maxCount, maxTokens int) []embedBatch {
+	var batches []embedBatch
+	start, tokens := 0, 0
+	for i, text := range texts {
+		n := EstimateTokens(text)
+		if i > start && (i-start >= maxCount || tokens+n > maxTokens) {
+			batches = append(batches, embedBatch{start, i})
+			start, tokens = i, 0
+		}
+		tokens += n
+	}
+	if start < len(texts) {
+		batches = append(batches, embedBatch{start, len(texts)})
+	}
+	return batches
+}
+
+// embedBatchWithRetry embeds one batch, retrying transient failures with
+// 200ms, 400ms, 800ms... backoff and checking that every input got exactly
+// one vector.
+func (c *Client) embedBatchWithRetry(ctx context.Context, req EmbeddingRequest, n, maxRetries int) (*EmbeddingResponse, error) {
+	var lastErr error
+	for attempt := 0; attempt <= max(maxRetries, 0); attempt++ {
+		if attempt > 0 {
+			backoff := time.Duration(200*(1<<uint(attempt-1))) * time.Millisecond
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(backoff):
+			}
+		}
+
+		resp, err := c.CreateEmbedding(ctx, req)
+		if err == nil {
+			err = checkEmbeddingIndexes(resp, n)
+		}
+		if err == nil {
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if !retryableEmbedError(err) {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// retryableEmbedError reports whether a failed batch may succeed when
+// resent: network errors, rate limits and server errors.
+func retryableEmbedError(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// checkEmbeddingIndexes verifies a response has one vector per input.
+func checkEmbeddingIndexes(resp *EmbeddingResponse, n int) error {
+	if len(resp.Data) != n {
+		return fmt.Errorf("expected %d embeddings, got %d", n, len(resp.Data))
+	}
+	seen := make([]bool, n)
+	for _, d := range resp.Data {
+		if d.Index < 0 || d.Index >= n || seen[d.Index] {
+			return fmt.Errorf("invalid embedding index %d", d.Index)
+		}
+		seen[d.Index] = true
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// newEmbedServer embeds "doc N" as [N], returns data in reverse order and
+// fails the first request with a 503.
+func newEmbedServer(t *testing.T, maxBatch int) (*Client, *int32) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var req struct {
+			Input []string `json:"input"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Input) > maxBatch {
+			t.Errorf("Batch of %d exceeds %d", len(req.Input), maxBatch)
+		}
+
+		resp := EmbeddingResponse{Usage: EmbeddingUsage{PromptTokens: len(req.Input), TotalTokens: len(req.Input)}}
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			var n float32
+			fmt.Sscanf(req.Input[i], "doc %f", &n)
+			resp.Data = append(resp.Data, EmbeddingData{Index: i, Embedding: []float32{n}})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(srv.Close)
+	return New("test-key", srv.URL), &calls
+}
+
+func TestEmbedAll(t *testing.T) {
+	c, calls := newEmbedServer(t, 7)
+
+	texts := make([]string, 100)
+	for i := range texts {
+		texts[i] = fmt.Sprintf("doc %d", i)
+	}
+
+	var mu sync.Mutex
+	var progress []int
+	res, err := c.EmbedAll(context.Background(), texts, EmbedOptions{
+		BatchSize:   7,
+		Concurrency: 3,
+		OnProgress: func(done, total int) {
+			mu.Lock()
+			progress = append(progress, done)
+			mu.Unlock()
+			if total != 100 {
+				t.Errorf("Unexpected total %d", total)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("EmbedAll failed: %v", err)
+	}
+
+	for i, v := range res.Vectors {
+		if len(v) != 1 || v[0] != float32(i) {
+			t.Fatalf("Vector %d out of order: %v", i, v)
+		}
+	}
+	if res.Usage.TotalTokens != 100 {
+		t.Errorf("Expected 100 tokens, got %d", res.Usage.TotalTokens)
+	}
+	if n := atomic.LoadInt32(calls); n != 16 { // 15 batches + 1 retry
+		t.Errorf("Expected 16 requests, got %d", n)
+	}
+	if len(progress) != 15 || progress[len(progress)-1] != 100 {
+		t.Errorf("Unexpected progress %v", progress)
+	}
+}
+
+func TestEmbedAllNoRetry(t *testing.T) {
+	c, _ := newEmbedServer(t, 64)
+	_, err := c.EmbedAll(context.Background(), []string{"doc 1"}, EmbedOptions{MaxRetries: -1})
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("Expected 503 error, got %v", err)
+	}
+}
+
+func TestEmbedAllRetriesOnlyTransientErrors(t *testing.T) {
+	for _, tc := range []struct {
+		status int
+		calls  int32
+	}{
+		{http.StatusTooManyRequests, 2},
+		{http.StatusBadGateway, 2},
+		{http.StatusBadRequest, 1},
+		{http.StatusRequestEntityTooLarge, 1},
+	} {
+		var calls int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.WriteHeader(tc.status)
+				w.Write([]byte(`{"error":{"message":"nope","type":"invalid_request_error"}}`))
+				return
+			}
+			json.NewEncoder(w).Encode(EmbeddingResponse{Data: []EmbeddingData{{Embedding: []float32{1}}}})
+		}))
+		c := New("test-key", srv.URL)
+
+		_, err := c.EmbedAll(context.Background(), []string{"doc 1"}, EmbedOptions{})
+		srv.Close()
+		if calls != tc.calls {
+			t.Errorf("HTTP %d: expected %d requests, got %d", tc.status, tc.calls, calls)
+		}
+		if (tc.calls == 1) != (err != nil) {
+			t.Errorf("HTTP %d: unexpected error %v", tc.status, err)
+		}
+	}
+
+	// Malformed responses are not retried either
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		json.NewEncoder(w).Encode(EmbeddingResponse{})
+	}))
+	defer srv.Close()
+	if _, err := New("test-key", srv.URL).EmbedAll(context.Background(), []string{"doc 1"}, EmbedOptions{}); err == nil || calls != 1 {
+		t.Errorf("Expected one failed request, got %d (%v)", calls, err)
+	}
+}
+
+func TestEmbedBatchesByTokens(t *testing.T) {
+	long := strings.Repeat("x", 400) // 100 tokens
+	texts := []string{"a", "b", long, "c", long, long}
+
+	batches := embedBatches(texts, 10, 150)
+	want := []embedBatch{{0, 4}, {4, 5}, {5, 6}}
+	if fmt.Sprint(batches) != fmt.Sprint(want) {
+		t.Errorf("Expected %v, got %v", want, batches)
+	}
+
+	if got := EstimateTokens(long); got != 100 {
+		t.Errorf("Unexpected estimate %d", got)
+	}
+}
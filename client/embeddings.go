@@ -3,13 +3,35 @@ package client
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
+	"sort"
 )
 
+// NewEmbeddingRequest builds a request embedding a single text.
+func NewEmbeddingRequest(text string) EmbeddingRequest {
+	return EmbeddingRequest{Input: text}
+}
+
+// NewBatchEmbeddingRequest builds a request embedding several texts.
+func NewBatchEmbeddingRequest(texts []string) EmbeddingRequest {
+	return EmbeddingRequest{Input: texts}
+}
+
 // CreateEmbedding generates embeddings for one or more text inputs.
+//
+// The request is checked locally first: Input must be a string or
+// []string. With EncodingFormat set to EncodingBase64 vectors are decoded
+// into EmbeddingData.Embedding as usual.
 func (c *Client) CreateEmbedding(ctx context.Context, req EmbeddingRequest) (*EmbeddingResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
 	body, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -30,7 +52,12 @@ func (c *Client) CreateEmbedding(ctx context.Context, req EmbeddingRequest) (*Em
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		if err := json.NewDecoder(resp.Body).Decode(apiErr); err != nil || apiErr.ErrorData.Message == "" {
+			apiErr.ErrorData.Type = "http_error"
+			apiErr.ErrorData.Message = fmt.Sprintf("API returned status %d", resp.StatusCode)
+		}
+		return nil, apiErr
 	}
 
 	var embResp EmbeddingResponse
@@ -73,3 +100,79 @@ func (c *Client) Rerank(ctx context.Context, req RerankRequest) (*RerankResponse
 
 	return &rerankResp, nil
 }
+
+// Validate checks the request locally.
+func (r EmbeddingRequest) Validate() error {
+	switch input := r.Input.(type) {
+	case string:
+	case []string:
+		if len(input) == 0 {
+			return fmt.Errorf("embedding input is empty")
+		}
+	default:
+		return fmt.Errorf("embedding input must be string or []string, got %T", r.Input)
+	}
+	switch r.EncodingFormat {
+	case "", EncodingFloat, EncodingBase64:
+	default:
+		return fmt.Errorf("unknown encoding_format %q", r.EncodingFormat)
+	}
+	if r.Dimensions < 0 {
+		return fmt.Errorf("dimensions must be positive, got %d", r.Dimensions)
+	}
+	return nil
+}
+
+// Vectors returns the embeddings ordered by input index, even if the
+// server returned them out of order.
+func (r *EmbeddingResponse) Vectors() [][]float32 {
+	data := make([]EmbeddingData, len(r.Data))
+	copy(data, r.Data)
+	sort.SliceStable(data, func(i, j int) bool { return data[i].Index < data[j].Index })
+
+	vectors := make([][]float32, len(data))
+	for i, d := range data {
+		vectors[i] = d.Embedding
+	}
+	return vectors
+}
+
+// UnmarshalJSON decodes the embedding from either a float array or a
+// base64 string of little-endian float32 values.
+func (d *EmbeddingData) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Object    string          `json:"object"`
+		Embedding json.RawMessage `json:"embedding"`
+		Index     int             `json:"index"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	d.Object = raw.Object
+	d.Index = raw.Index
+	d.Embedding = nil
+
+	if len(raw.Embedding) == 0 || string(raw.Embedding) == "null" {
+		return nil
+	}
+	if raw.Embedding[0] != '"' {
+		return json.Unmarshal(raw.Embedding, &d.Embedding)
+	}
+
+	var encoded string
+	if err := json.Unmarshal(raw.Embedding, &encoded); err != nil {
+		return err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("invalid base64 embedding: %w", err)
+	}
+	if len(decoded)%4 != 0 {
+		return fmt.Errorf("invalid base64 embedding length %d", len(decoded))
+	}
+	d.Embedding = make([]float32, len(decoded)/4)
+	for i := range d.Embedding {
+		d.Embedding[i] = math.Float32frombits(binary.LittleEndian.Uint32(decoded[i*4:]))
+	}
+	return nil
+}
@@ -2,7 +2,15 @@ package client
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"reflect"
 	"testing"
 )
 
@@ -113,3 +121,62 @@ func TestRerank(t *testing.T) {
 		}
 	})
 }
+
+func TestEmbeddingBase64AndVectors(t *testing.T) {
+	encode := func(vals ...float32) string {
+		buf := make([]byte, 4*len(vals))
+		for i, v := range vals {
+			binary.LittleEndian.PutUint32(buf[i*4:], math.Float32bits(v))
+		}
+		return base64.StdEncoding.EncodeToString(buf)
+	}
+
+	var sent map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		fmt.Fprintf(w, `{"data":[
+			{"object":"embedding","index":1,"embedding":%q},
+			{"object":"embedding","index":0,"embedding":%q}
+		],"usage":{"prompt_tokens":4,"total_tokens":4}}`, encode(3, 4), encode(1, -2.5))
+	}))
+	defer srv.Close()
+	c := New("test-key", srv.URL)
+
+	req := NewBatchEmbeddingRequest([]string{"a", "b"})
+	req.EncodingFormat = EncodingBase64
+	req.Dimensions = 2
+	req.User = "user_1"
+	resp, err := c.CreateEmbedding(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CreateEmbedding failed: %v", err)
+	}
+
+	if sent["encoding_format"] != "base64" || sent["dimensions"] != float64(2) || sent["user"] != "user_1" {
+		t.Errorf("Options not sent: %v", sent)
+	}
+	want := [][]float32{{1, -2.5}, {3, 4}}
+	if got := resp.Vectors(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if resp.Data[0].Index != 1 {
+		t.Error("Vectors must not reorder Data")
+	}
+}
+
+func TestEmbeddingRequestValidate(t *testing.T) {
+	c := New("test-key", "http://127.0.0.1:9")
+	bad := []EmbeddingRequest{
+		{Input: []int{1, 2}},
+		{Input: []string{}},
+		{Input: "x", EncodingFormat: "binary"},
+		{Input: "x", Dimensions: -1},
+	}
+	for _, req := range bad {
+		if _, err := c.CreateEmbedding(context.Background(), req); err == nil {
+			t.Errorf("Expected validation error for %+v", req)
+		}
+	}
+	if err := NewEmbeddingRequest("x").Validate(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -1,6 +1,9 @@
 package client
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // APIError represents an error returned by the PixiGPT API (OpenAI format).
 type APIError struct {
@@ -20,6 +23,14 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("[%s] %s", e.ErrorData.Type, e.ErrorData.Message)
 }
 
+// ErrNoChoices is returned by ChatCompletionResponse helpers when the
+// response has no choices.
+var ErrNoChoices = errors.New("chat completion has no choices")
+
+// ErrStreamEnded is returned when a run stream ends before the run reaches
+// a terminal status, e.g. because the connection dropped.
+var ErrStreamEnded = errors.New("stream ended before run finished")
+
 // IsAuthError returns true if the error is an authentication error.
 func IsAuthError(err error) bool {
 	apiErr, ok := err.(*APIError)
@@ -31,3 +42,30 @@ func IsRateLimitError(err error) bool {
 	apiErr, ok := err.(*APIError)
 	return ok && apiErr.ErrorData.Type == "rate_limit_error"
 }
+
+// RunAbortError is returned by WaitForRun when its context ends and
+// WithCancelOnDone requested a server-side cancel.
+type RunAbortError struct {
+	ThreadID  string
+	RunID     string
+	Err       error // ctx.Err() that ended the wait
+	CancelErr error // nil if the run was cancelled successfully
+}
+
+// Error implements the error interface.
+func (e *RunAbortError) Error() string {
+	if e.CancelErr != nil {
+		return fmt.Sprintf("run %s abandoned: %v (cancel failed: %v)", e.RunID, e.Err, e.CancelErr)
+	}
+	return fmt.Sprintf("run %s cancelled: %v", e.RunID, e.Err)
+}
+
+// Unwrap returns the context error so errors.Is(err, context.Canceled) works.
+func (e *RunAbortError) Unwrap() error {
+	return e.Err
+}
+
+// Cancelled reports whether the server-side cancel succeeded.
+func (e *RunAbortError) Cancelled() bool {
+	return e.CancelErr == nil
+}
@@ -0,0 +1,294 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMaxMediaBytes is the default download limit for DownloadMedia.
+const DefaultMaxMediaBytes = 50 << 20 // 50 MiB
+
+// ErrMediaTooLarge is returned when a download exceeds MediaDownloadOptions.MaxBytes.
+var ErrMediaTooLarge = errors.New("media exceeds size limit")
+
+// ErrSignedURLExpired is returned when a signed URL has expired and could
+// not be refreshed.
+var ErrSignedURLExpired = errors.New("signed URL expired")
+
+// safeMediaID matches media IDs usable as file names.
+var safeMediaID = regexp.MustCompile(`^[A-Za-z0-9_-]{1,128}$`)
+
+// MediaDownloadOptions configures DownloadMedia and DownloadMediaToDir.
+type MediaDownloadOptions struct {
+	// MaxBytes caps the download size (default DefaultMaxMediaBytes).
+	MaxBytes int64
+	// RefreshMargin refreshes URLs that expire within this window before
+	// downloading (default 1 minute).
+	RefreshMargin time.Duration
+	// NoRefresh disables re-fetching the message for a fresh URL.
+	NoRefresh bool
+}
+
+// MediaDownload describes a completed download.
+type MediaDownload struct {
+	Media       MessageMedia // With the URL actually used (refreshed if needed)
+	ContentType string       // From the response, sniffed when missing or generic
+	Size        int64
+	Path        string // Set by DownloadMediaToDir
+	Refreshed   bool   // The signed URL was re-fetched
+}
+
+// SignedURLExpiry returns when a presigned (S3/R2 SigV4) URL expires,
+// computed from its X-Amz-Date and X-Amz-Expires query parameters.
+func SignedURLExpiry(signedURL string) (time.Time, error) {
+	u, err := url.Parse(signedURL)
+	if err != nil {
+		return time.Time{}, err
+	}
+	query := u.Query()
+
+	date := query.Get("X-Amz-Date")
+	expires := query.Get("X-Amz-Expires")
+	if date == "" || expires == "" {
+		return time.Time{}, fmt.Errorf("URL has no X-Amz-Date/X-Amz-Expires parameters")
+	}
+
+	signedAt, err := time.Parse("20060102T150405Z", date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid X-Amz-Date %q: %w", date, err)
+	}
+	seconds, err := strconv.Atoi(expires)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid X-Amz-Expires %q: %w", expires, err)
+	}
+	return signedAt.Add(time.Duration(seconds) * time.Second), nil
+}
+
+// ExpiresAt returns when the media's signed URL expires.
+func (m MessageMedia) ExpiresAt() (time.Time, error) {
+	return SignedURLExpiry(m.SignedURL)
+}
+
+// DownloadMedia streams one media attachment of msg to w.
+//
+// Expired (or soon to expire) signed URLs are refreshed by re-listing the
+// message with ListMessagesPage; msg.Media is replaced with the fresh URLs.
+// A URL rejected by storage with 400/403 is refreshed once and retried.
+// On ErrMediaTooLarge w may already hold a partial download.
+//
+// Example (archive generated images before their URLs expire):
+//
+//	for _, media := range msg.MediaByTool(ToolDrawImage) {
+//	    f, _ := os.Create(media.ID + ".png")
+//	    _, err := client.DownloadMedia(ctx, msg, media.ID, f, MediaDownloadOptions{})
+//	    f.Close()
+//	}
+func (c *Client) DownloadMedia(ctx context.Context, msg *ThreadMessage, mediaID string, w io.Writer, opts MediaDownloadOptions) (*MediaDownload, error) {
+	return c.downloadMedia(ctx, msg, mediaID, w, opts)
+}
+
+// DownloadMediaToDir downloads every media attachment of msg into dir as
+// "<media ID><ext>", with the extension chosen from the content type.
+// Files are written atomically; a failed download leaves no file behind.
+// Media IDs other than letters, digits, '_' and '-' are rejected before
+// anything is written.
+func (c *Client) DownloadMediaToDir(ctx context.Context, msg *ThreadMessage, dir string, opts MediaDownloadOptions) ([]MediaDownload, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	for _, media := range msg.Media {
+		if !safeMediaID.MatchString(media.ID) {
+			return nil, fmt.Errorf("unsafe media ID %q in message %s", media.ID, msg.ID)
+		}
+	}
+
+	var downloads []MediaDownload
+	for _, media := range msg.Media {
+		tmp, err := os.CreateTemp(dir, "."+media.ID+"-*")
+		if err != nil {
+			return downloads, err
+		}
+
+		d, err := c.downloadMedia(ctx, msg, media.ID, tmp, opts)
+		if closeErr := tmp.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(tmp.Name())
+			return downloads, err
+		}
+
+		d.Path = filepath.Join(dir, media.ID+mediaExtension(d.ContentType))
+		if err := os.Rename(tmp.Name(), d.Path); err != nil {
+			os.Remove(tmp.Name())
+			return downloads, err
+		}
+		downloads = append(downloads, *d)
+	}
+	return downloads, nil
+}
+
+// downloadMedia fetches one attachment, refreshing its URL when needed.
+func (c *Client) downloadMedia(ctx context.Context, msg *ThreadMessage, mediaID string, w io.Writer, opts MediaDownloadOptions) (*MediaDownload, error) {
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = DefaultMaxMediaBytes
+	}
+	if opts.RefreshMargin <= 0 {
+		opts.RefreshMargin = time.Minute
+	}
+
+	idx := mediaIndex(msg, mediaID)
+	if idx < 0 {
+		return nil, fmt.Errorf("media %s not found in message %s", mediaID, msg.ID)
+	}
+
+	refreshed := false
+	refresh := func() error {
+		if opts.NoRefresh || refreshed || msg.ThreadID == "" {
+			return ErrSignedURLExpired
+		}
+		fresh, err := c.listMessage(ctx, msg.ThreadID, msg.ID)
+		if err != nil {
+			return fmt.Errorf("refresh media %s: %w", mediaID, err)
+		}
+		if idx = mediaIndex(fresh, mediaID); idx < 0 {
+			return fmt.Errorf("media %s no longer in message %s", mediaID, msg.ID)
+		}
+		// Every attachment was re-signed; keep them all for later downloads
+		msg.Media = fresh.Media
+		refreshed = true
+		return nil
+	}
+
+	if expiry, err := msg.Media[idx].ExpiresAt(); err == nil && time.Until(expiry) < opts.RefreshMargin {
+		if err := refresh(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Media can be large: rely on ctx rather than the client timeout
+	downloadClient := *c.httpClient
+	downloadClient.Timeout = 0
+
+	for {
+		req, err := http.NewRequestWithContext(ctx, "GET", msg.Media[idx].SignedURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		// Signed URLs carry their own credentials - no API key
+		resp, err := downloadClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("download media %s: %w", mediaID, err)
+		}
+
+		if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusBadRequest {
+			resp.Body.Close()
+			if err := refresh(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if resp.StatusCode >= 400 {
+			resp.Body.Close()
+			return nil, fmt.Errorf("download media %s: HTTP %d", mediaID, resp.StatusCode)
+		}
+
+		d, err := copyMedia(resp, w, opts.MaxBytes)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("download media %s: %w", mediaID, err)
+		}
+		d.Media = msg.Media[idx]
+		d.Refreshed = refreshed
+		return d, nil
+	}
+}
+
+// listMessage re-lists a single message, which re-signs its media URLs:
+// the message before it (newest first) is the cursor for a one-message page
+// in oldest-first order.
+func (c *Client) listMessage(ctx context.Context, threadID, messageID string) (*ThreadMessage, error) {
+	prev, err := c.ListMessagesPage(ctx, threadID, MessageListParams{Limit: 1, Order: "desc", After: messageID})
+	if err != nil {
+		return nil, err
+	}
+	params := MessageListParams{Limit: 1, Order: "asc"}
+	if len(prev.Data) > 0 {
+		params.After = prev.Data[0].ID
+	}
+
+	page, err := c.ListMessagesPage(ctx, threadID, params)
+	if err != nil {
+		return nil, err
+	}
+	if len(page.Data) == 0 || page.Data[0].ID != messageID {
+		return nil, fmt.Errorf("message %s not found in thread %s", messageID, threadID)
+	}
+	return &page.Data[0], nil
+}
+
+// copyMedia streams a response body, enforcing maxBytes and detecting the
+// content type.
+func copyMedia(resp *http.Response, w io.Writer, maxBytes int64) (*MediaDownload, error) {
+	if resp.ContentLength > maxBytes {
+		return nil, fmt.Errorf("%w: %d > %d bytes", ErrMediaTooLarge, resp.ContentLength, maxBytes)
+	}
+
+	body := bufio.NewReaderSize(resp.Body, 512)
+	contentType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if contentType == "" || contentType == "application/octet-stream" || contentType == "binary/octet-stream" {
+		head, _ := body.Peek(512)
+		contentType, _, _ = mime.ParseMediaType(http.DetectContentType(head))
+	}
+
+	n, err := io.Copy(w, io.LimitReader(body, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if n > maxBytes {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrMediaTooLarge, maxBytes)
+	}
+	return &MediaDownload{ContentType: contentType, Size: n}, nil
+}
+
+func mediaIndex(msg *ThreadMessage, mediaID string) int {
+	for i, m := range msg.Media {
+		if m.ID == mediaID {
+			return i
+		}
+	}
+	return -1
+}
+
+// mediaExtension picks a file extension for a content type.
+func mediaExtension(contentType string) string {
+	switch contentType {
+	case "image/jpeg":
+		return ".jpg"
+	case "audio/mpeg":
+		return ".mp3"
+	case "audio/wave", "audio/x-wav":
+		return ".wav"
+	}
+	if exts, _ := mime.ExtensionsByType(contentType); len(exts) > 0 {
+		return exts[0]
+	}
+	if _, sub, ok := strings.Cut(contentType, "/"); ok && sub != "" && !strings.ContainsAny(sub, "+.;") {
+		return "." + sub
+	}
+	return ".bin"
+}
@@ -0,0 +1,190 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n0000000000")
+
+func signedQuery(signedAt time.Time) string {
+	return fmt.Sprintf("X-Amz-Date=%s&X-Amz-Expires=86400&X-Amz-Signature=abc", signedAt.UTC().Format("20060102T150405Z"))
+}
+
+func TestSignedURLExpiry(t *testing.T) {
+	expiry, err := SignedURLExpiry("https://r2.example.com/img.png?X-Amz-Date=20250101T120000Z&X-Amz-Expires=86400")
+	if err != nil {
+		t.Fatalf("SignedURLExpiry failed: %v", err)
+	}
+	if want := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC); !expiry.Equal(want) {
+		t.Errorf("Expected %v, got %v", want, expiry)
+	}
+
+	if _, err := SignedURLExpiry("https://example.com/img.png"); err == nil {
+		t.Error("Expected error for unsigned URL")
+	}
+}
+
+// newMediaServer serves images from a three-message thread; msg_1 holds
+// img_1 and img_2, re-signed on every listing. URLs signed "stale" are
+// rejected with 403, as R2 does for expired signatures. The counter
+// tracks listings returning msg_1.
+func newMediaServer(t *testing.T, body []byte, contentType string) (*Client, *httptest.Server, *int) {
+	refreshes := 0
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/threads/thread_1/messages":
+			thread := []ThreadMessage{{ID: "msg_0"}, {ID: "msg_1", Media: []MessageMedia{
+				{ID: "img_1", Type: "image", SignedURL: srv.URL + "/media/img_1?" + signedQuery(time.Now())},
+				{ID: "img_2", Type: "image", SignedURL: srv.URL + "/media/img_1?" + signedQuery(time.Now())},
+			}}, {ID: "msg_2"}}
+			query := r.URL.Query()
+			if query.Get("order") == "desc" {
+				slices.Reverse(thread)
+			}
+			if after := query.Get("after"); after != "" {
+				i := slices.IndexFunc(thread, func(m ThreadMessage) bool { return m.ID == after })
+				thread = thread[i+1:]
+			}
+			limit, _ := strconv.Atoi(query.Get("limit"))
+			page := thread[:min(limit, len(thread))]
+			if slices.ContainsFunc(page, func(m ThreadMessage) bool { return m.ID == "msg_1" }) {
+				refreshes++
+			}
+			json.NewEncoder(w).Encode(MessageList{Data: page, HasMore: len(page) < len(thread)})
+		case "/media/img_1":
+			if r.Header.Get("Authorization") != "" {
+				t.Error("API key must not be sent to storage")
+			}
+			if r.URL.Query().Get("X-Amz-Signature") == "stale" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			if contentType != "" {
+				w.Header().Set("Content-Type", contentType)
+			}
+			w.Write(body)
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return New("test-key", srv.URL, WithRetryMax(0)), srv, &refreshes
+}
+
+func TestDownloadMediaRefreshesExpiredURL(t *testing.T) {
+	c, srv, refreshes := newMediaServer(t, pngHeader, "application/octet-stream")
+	msg := &ThreadMessage{ID: "msg_1", ThreadID: "thread_1", Media: []MessageMedia{{
+		ID: "img_1", Type: "image", SignedURL: srv.URL + "/media/img_1?" + signedQuery(time.Now().Add(-48*time.Hour)),
+	}}}
+	old := msg.Media[0].SignedURL
+
+	var buf bytes.Buffer
+	d, err := c.DownloadMedia(context.Background(), msg, "img_1", &buf, MediaDownloadOptions{})
+	if err != nil {
+		t.Fatalf("DownloadMedia failed: %v", err)
+	}
+	if *refreshes != 1 || !d.Refreshed || msg.Media[0].SignedURL == old {
+		t.Errorf("Expected one refresh updating the message, got %d (%v)", *refreshes, d.Refreshed)
+	}
+	if d.ContentType != "image/png" || d.Size != int64(len(pngHeader)) || !bytes.Equal(buf.Bytes(), pngHeader) {
+		t.Errorf("Unexpected download %+v", d)
+	}
+}
+
+func TestDownloadMediaRetriesForbidden(t *testing.T) {
+	c, srv, refreshes := newMediaServer(t, pngHeader, "image/png")
+	msg := &ThreadMessage{ID: "msg_1", ThreadID: "thread_1", Media: []MessageMedia{{
+		ID: "img_1", SignedURL: srv.URL + "/media/img_1?X-Amz-Signature=stale",
+	}}}
+
+	if _, err := c.DownloadMedia(context.Background(), msg, "img_1", &bytes.Buffer{}, MediaDownloadOptions{NoRefresh: true}); !errors.Is(err, ErrSignedURLExpired) {
+		t.Errorf("Expected ErrSignedURLExpired, got %v", err)
+	}
+
+	if _, err := c.DownloadMedia(context.Background(), msg, "img_1", &bytes.Buffer{}, MediaDownloadOptions{}); err != nil {
+		t.Fatalf("DownloadMedia failed: %v", err)
+	}
+	if *refreshes != 1 {
+		t.Errorf("Expected 1 refresh, got %d", *refreshes)
+	}
+}
+
+func TestDownloadMediaToDir(t *testing.T) {
+	c, srv, _ := newMediaServer(t, pngHeader, "")
+	msg := &ThreadMessage{ID: "msg_1", ThreadID: "thread_1", Media: []MessageMedia{{
+		ID: "img_1", SignedURL: srv.URL + "/media/img_1?" + signedQuery(time.Now()),
+	}}}
+	dir := t.TempDir()
+
+	downloads, err := c.DownloadMediaToDir(context.Background(), msg, dir, MediaDownloadOptions{})
+	if err != nil {
+		t.Fatalf("DownloadMediaToDir failed: %v", err)
+	}
+	if len(downloads) != 1 || downloads[0].Path != filepath.Join(dir, "img_1.png") {
+		t.Fatalf("Unexpected downloads %+v", downloads)
+	}
+	if data, _ := os.ReadFile(downloads[0].Path); !bytes.Equal(data, pngHeader) {
+		t.Errorf("Unexpected file content %q", data)
+	}
+
+	_, err = c.DownloadMediaToDir(context.Background(), msg, dir, MediaDownloadOptions{MaxBytes: 4})
+	if !errors.Is(err, ErrMediaTooLarge) {
+		t.Errorf("Expected ErrMediaTooLarge, got %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("Failed download left files behind: %v", entries)
+	}
+}
+
+func TestDownloadMediaToDirRejectsUnsafeID(t *testing.T) {
+	c, srv, _ := newMediaServer(t, pngHeader, "image/png")
+	dir := filepath.Join(t.TempDir(), "media")
+	for _, id := range []string{"../escape", "a/b", "..", ""} {
+		msg := &ThreadMessage{ID: "msg_1", ThreadID: "thread_1", Media: []MessageMedia{
+			{ID: "img_1", SignedURL: srv.URL + "/media/img_1?" + signedQuery(time.Now())},
+			{ID: id, SignedURL: srv.URL + "/media/img_1?" + signedQuery(time.Now())},
+		}}
+		if _, err := c.DownloadMediaToDir(context.Background(), msg, dir, MediaDownloadOptions{}); err == nil {
+			t.Errorf("Expected error for media ID %q", id)
+		}
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(dir)); len(entries) != 1 {
+		t.Errorf("Unsafe ID wrote outside dir: %v", entries)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Rejected message left files behind: %v", entries)
+	}
+}
+
+func TestDownloadMediaToDirRefreshesOnce(t *testing.T) {
+	c, srv, refreshes := newMediaServer(t, pngHeader, "image/png")
+	expired := srv.URL + "/media/img_1?" + signedQuery(time.Now().Add(-48*time.Hour))
+	msg := &ThreadMessage{ID: "msg_1", ThreadID: "thread_1", Media: []MessageMedia{
+		{ID: "img_1", SignedURL: expired},
+		{ID: "img_2", SignedURL: expired},
+	}}
+
+	downloads, err := c.DownloadMediaToDir(context.Background(), msg, t.TempDir(), MediaDownloadOptions{})
+	if err != nil {
+		t.Fatalf("DownloadMediaToDir failed: %v", err)
+	}
+	if len(downloads) != 2 || *refreshes != 1 {
+		t.Errorf("Expected 2 downloads after 1 refresh, got %d after %d", len(downloads), *refreshes)
+	}
+	if msg.Media[1].SignedURL == expired {
+		t.Error("Refresh did not update every attachment")
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 )
 
 // CreateMessage adds a message to a thread.
@@ -28,8 +31,11 @@ func (c *Client) CreateMessage(ctx context.Context, threadID string, role, conte
 
 // BulkMessage represents a message in a bulk create request.
 type BulkMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role             string     `json:"role"`
+	Content          string     `json:"content"`
+	ToolCalls        []ToolCall `json:"tool_calls,omitempty"`
+	ToolCallID       *string    `json:"tool_call_id,omitempty"` // For role="tool" messages
+	ReasoningContent string     `json:"reasoning_content,omitempty"`
 }
 
 // CreateMessagesBulk adds multiple messages to a thread in one request.
@@ -59,23 +65,182 @@ func (c *Client) CreateMessagesBulk(ctx context.Context, threadID string, messag
 //
 // Chain of thought reasoning is automatically extracted from <think> tags.
 func (c *Client) ListMessages(ctx context.Context, threadID string, limit int) ([]ThreadMessage, error) {
-	if limit == 0 {
-		limit = 20
+	page, err := c.ListMessagesPage(ctx, threadID, MessageListParams{Limit: limit})
+	if err != nil {
+		return nil, err
 	}
 
-	var resp struct {
-		Object  string          `json:"object"`
-		Data    []ThreadMessage `json:"data"`
-		FirstID string          `json:"first_id,omitempty"`
-		LastID  string          `json:"last_id,omitempty"`
-		HasMore bool            `json:"has_more"`
+	// Server now returns reasoning_content directly - no parsing needed
+	return page.Data, nil
+}
+
+// MessageListParams configures ListMessagesPage.
+type MessageListParams struct {
+	Limit  int    // Page size, default 20
+	Order  string // "asc" (oldest first) or "desc" (server default)
+	After  string // Return messages after this message ID
+	Before string // Return messages before this message ID
+}
+
+// MessageList is one page of thread messages.
+type MessageList struct {
+	Object  string          `json:"object"`
+	Data    []ThreadMessage `json:"data"`
+	FirstID string          `json:"first_id,omitempty"`
+	LastID  string          `json:"last_id,omitempty"`
+	HasMore bool            `json:"has_more"`
+}
+
+// ListMessagesPage retrieves one page of messages with cursor pagination.
+func (c *Client) ListMessagesPage(ctx context.Context, threadID string, params MessageListParams) (*MessageList, error) {
+	if params.Limit == 0 {
+		params.Limit = 20
 	}
 
-	path := fmt.Sprintf("/threads/%s/messages?limit=%d", threadID, limit)
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(params.Limit))
+	if params.Order != "" {
+		query.Set("order", params.Order)
+	}
+	if params.After != "" {
+		query.Set("after", params.After)
+	}
+	if params.Before != "" {
+		query.Set("before", params.Before)
+	}
+
+	var resp MessageList
+	path := fmt.Sprintf("/threads/%s/messages?%s", threadID, query.Encode())
 	if err := c.doRequest(ctx, "GET", path, nil, &resp); err != nil {
 		return nil, err
 	}
 
-	// Server now returns reasoning_content directly - no parsing needed
-	return resp.Data, nil
+	return &resp, nil
+}
+
+// ListAllMessages pages through a thread and returns every message,
+// oldest first.
+func (c *Client) ListAllMessages(ctx context.Context, threadID string) ([]ThreadMessage, error) {
+	return c.messagesUntil(ctx, threadID, "", false)
+}
+
+// messagesUntil pages through a thread oldest first and stops at
+// untilMessageID (all messages if empty).
+func (c *Client) messagesUntil(ctx context.Context, threadID, untilMessageID string, exclusive bool) ([]ThreadMessage, error) {
+	var messages []ThreadMessage
+	params := MessageListParams{Limit: 100, Order: "asc"}
+	for {
+		page, err := c.ListMessagesPage(ctx, threadID, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range page.Data {
+			if m.ID == untilMessageID && untilMessageID != "" {
+				if !exclusive {
+					messages = append(messages, m)
+				}
+				return messages, nil
+			}
+			messages = append(messages, m)
+		}
+
+		if !page.HasMore || len(page.Data) == 0 {
+			break
+		}
+		params.After = page.Data[len(page.Data)-1].ID
+	}
+
+	if untilMessageID != "" {
+		return nil, fmt.Errorf("message %s not found in thread %s", untilMessageID, threadID)
+	}
+	return messages, nil
+}
+
+// BulkMessageCreator is the subset of *Client used by CopyMessages.
+type BulkMessageCreator interface {
+	CreateMessagesBulk(ctx context.Context, threadID string, messages []BulkMessage) ([]ThreadMessage, error)
+}
+
+// CopyMessages appends messages to a thread in order, keeping roles, text,
+// tool calls and reasoning, using bulk requests of at most batchSize
+// messages (default 100). ForkThread, ImportBundle and transcript.Import
+// all recreate threads this way.
+func CopyMessages(ctx context.Context, api BulkMessageCreator, threadID string, messages []ThreadMessage, batchSize int) error {
+	bulk := make([]BulkMessage, 0, len(messages))
+	for _, m := range messages {
+		bulk = append(bulk, bulkMessage(m, true))
+	}
+	return createMessagesBatched(ctx, api, threadID, bulk, batchSize)
+}
+
+// createMessagesBatched adds messages in order using bulk requests of at
+// most batchSize messages (default 100).
+func createMessagesBatched(ctx context.Context, api BulkMessageCreator, threadID string, messages []BulkMessage, batchSize int) error {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	for start := 0; start < len(messages); start += batchSize {
+		end := min(start+batchSize, len(messages))
+		if _, err := api.CreateMessagesBulk(ctx, threadID, messages[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Text joins the text parts of the message with newlines.
+func (m *ThreadMessage) Text() string {
+	var parts []string
+	for _, content := range m.Content {
+		if content.Type == "text" || content.Type == "" {
+			parts = append(parts, content.Text.Value)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// Annotations returns the annotations of all text parts, in order.
+func (m *ThreadMessage) Annotations() []Annotation {
+	var annotations []Annotation
+	for _, content := range m.Content {
+		annotations = append(annotations, content.Text.Annotations...)
+	}
+	return annotations
+}
+
+// SourcesByTool returns the sources produced by the named tool
+// (e.g. ToolWebSearch).
+func (m *ThreadMessage) SourcesByTool(toolName string) []MessageSource {
+	var sources []MessageSource
+	for _, src := range m.Sources {
+		if src.ToolName == toolName {
+			sources = append(sources, src)
+		}
+	}
+	return sources
+}
+
+// MediaByTool returns the media produced by the named tool
+// (e.g. ToolDrawImage, or "UserUpload").
+func (m *ThreadMessage) MediaByTool(toolName string) []MessageMedia {
+	var media []MessageMedia
+	for _, item := range m.Media {
+		if item.Source == toolName {
+			media = append(media, item)
+		}
+	}
+	return media
+}
+
+// CodeByLanguage returns the code executions in the given language.
+// All code comes from ToolExecuteCode, so it is filtered by language instead.
+func (m *ThreadMessage) CodeByLanguage(language string) []MessageCode {
+	var code []MessageCode
+	for _, item := range m.Code {
+		if strings.EqualFold(item.Language, language) {
+			code = append(code, item)
+		}
+	}
+	return code
 }
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestThreadMessageAccessors(t *testing.T) {
+	raw := `{
+		"id": "msg_1",
+		"role": "assistant",
+		"content": [
+			{"type": "text", "text": {"value": "Go 1.23 is out [1].", "annotations": [
+				{"type": "url_citation", "text": "[1]", "start_index": 15, "end_index": 18,
+				 "url_citation": {"url": "https://go.dev/blog", "title": "Go Blog"}}
+			]}},
+			{"type": "text", "text": {"value": "See the file.", "annotations": [
+				{"type": "file_citation", "start_index": 8, "end_index": 12, "file_citation": {"file_id": "file_1"}}
+			]}}
+		],
+		"sources": [
+			{"id": "s1", "tool_name": "WebSearch"},
+			{"id": "s2", "tool_name": "Fetch"},
+			{"id": "s3", "tool_name": "WebSearch"}
+		],
+		"media": [
+			{"id": "m1", "source": "DrawImage", "type": "image"},
+			{"id": "m2", "source": "UserUpload", "type": "image"}
+		],
+		"code": [
+			{"id": "c1", "language": "python", "code": "print(1)"},
+			{"id": "c2", "language": "bash", "code": "ls"}
+		]
+	}`
+	var m ThreadMessage
+	if err := json.Unmarshal([]byte(raw), &m); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if got := m.Text(); got != "Go 1.23 is out [1].\nSee the file." {
+		t.Errorf("Unexpected text %q", got)
+	}
+
+	annotations := m.Annotations()
+	if len(annotations) != 2 {
+		t.Fatalf("Expected 2 annotations, got %d", len(annotations))
+	}
+	a := annotations[0]
+	if a.URLCitation == nil || a.URLCitation.URL != "https://go.dev/blog" || a.StartIndex != 15 || a.EndIndex != 18 {
+		t.Errorf("Unexpected url citation %+v", a)
+	}
+	if got := m.Content[0].Text.Value[a.StartIndex:a.EndIndex]; got != "[1]" {
+		t.Errorf("Span mismatch: %q", got)
+	}
+	if f := annotations[1].FileCitation; f == nil || f.FileID != "file_1" {
+		t.Errorf("Unexpected file citation %+v", annotations[1])
+	}
+
+	if got := m.SourcesByTool(ToolWebSearch); len(got) != 2 || got[1].ID != "s3" {
+		t.Errorf("Unexpected sources %+v", got)
+	}
+	if got := m.MediaByTool(ToolDrawImage); len(got) != 1 || got[0].ID != "m1" {
+		t.Errorf("Unexpected media %+v", got)
+	}
+	if got := m.CodeByLanguage("Python"); len(got) != 1 || got[0].ID != "c1" {
+		t.Errorf("Unexpected code %+v", got)
+	}
+}
+
+func TestChatCompletionResponseHelpers(t *testing.T) {
+	var empty ChatCompletionResponse
+	if _, err := empty.Content(); !errors.Is(err, ErrNoChoices) {
+		t.Errorf("Expected ErrNoChoices, got %v", err)
+	}
+	if _, err := empty.Reasoning(); !errors.Is(err, ErrNoChoices) {
+		t.Errorf("Expected ErrNoChoices, got %v", err)
+	}
+	var nilResp *ChatCompletionResponse
+	if _, err := nilResp.FirstChoice(); !errors.Is(err, ErrNoChoices) {
+		t.Errorf("Expected ErrNoChoices for nil response, got %v", err)
+	}
+
+	resp := ChatCompletionResponse{Choices: []ChatCompletionChoice{{
+		Message:          Message{Role: "assistant", Content: "Hi"},
+		ReasoningContent: "greet",
+	}}}
+	if content, err := resp.Content(); err != nil || content != "Hi" {
+		t.Errorf("Unexpected content %q (%v)", content, err)
+	}
+	if reasoning, err := resp.Reasoning(); err != nil || reasoning != "greet" {
+		t.Errorf("Unexpected reasoning %q (%v)", reasoning, err)
+	}
+}
+
+type fakeBulkCreator struct{ batches [][]BulkMessage }
+
+func (f *fakeBulkCreator) CreateMessagesBulk(ctx context.Context, threadID string, messages []BulkMessage) ([]ThreadMessage, error) {
+	f.batches = append(f.batches, append([]BulkMessage(nil), messages...))
+	return nil, nil
+}
+
+func TestCopyMessagesBatches(t *testing.T) {
+	callID := "call_1"
+	messages := []ThreadMessage{
+		{Role: "user", Content: []MessageContent{{Type: "text", Text: MessageContentText{Value: "hi"}}}},
+		{Role: "assistant", ReasoningContent: "think", ToolCalls: []ToolCall{{ID: callID}}},
+		{Role: "tool", ToolCallID: &callID, Content: []MessageContent{{Type: "text", Text: MessageContentText{Value: "42"}}}},
+	}
+
+	api := &fakeBulkCreator{}
+	if err := CopyMessages(context.Background(), api, "thread_1", messages, 2); err != nil {
+		t.Fatalf("CopyMessages failed: %v", err)
+	}
+	if len(api.batches) != 2 || len(api.batches[0]) != 2 || len(api.batches[1]) != 1 {
+		t.Fatalf("Expected batches of 2 and 1, got %+v", api.batches)
+	}
+	second, third := api.batches[0][1], api.batches[1][0]
+	if second.ReasoningContent != "think" || len(second.ToolCalls) != 1 || third.Content != "42" || *third.ToolCallID != callID {
+		t.Errorf("Messages not copied faithfully: %+v %+v", second, third)
+	}
+}
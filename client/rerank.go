@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Reranker is implemented by *Client; RerankItems accepts any Reranker so
+// it can be used with wrappers and fakes.
+type Reranker interface {
+	Rerank(ctx context.Context, req RerankRequest) (*RerankResponse, error)
+}
+
+// RerankOptions configures RerankItems.
+type RerankOptions struct {
+	TopK      int     // Max items returned after merging, 0 = all
+	MinScore  float32 // Drop items scoring below this
+	BatchSize int     // Max documents per request (default 100)
+	Model     string  // Passed to every request
+}
+
+// RankedItem is an item with its relevance to the query.
+type RankedItem[T any] struct {
+	Item  T
+	Index int // Position in the input slice
+	Score float32
+}
+
+// RerankItems reranks structured items by relevance to query, using text
+// to extract the document to score from each item.
+//
+// Item lists larger than BatchSize are scored in batches; relevance
+// scores are absolute per query/document pair, so batches are merged into
+// one global ranking before MinScore and TopK are applied. Ties keep input
+// order.
+//
+// Example:
+//
+//	ranked, err := client.RerankItems(ctx, c, "refund policy", tickets,
+//	    func(t Ticket) string { return t.Subject + "\n" + t.Body },
+//	    client.RerankOptions{TopK: 10, MinScore: 0.3})
+//	for _, r := range ranked {
+//	    fmt.Println(r.Score, r.Item.ID)
+//	}
+func RerankItems[T any](ctx context.Context, r Reranker, query string, items []T, text func(T) string, opts RerankOptions) ([]RankedItem[T], error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+
+	ranked := make([]RankedItem[T], 0, len(items))
+	for start := 0; start < len(items); start += opts.BatchSize {
+		end := min(start+opts.BatchSize, len(items))
+
+		docs := make([]string, end-start)
+		for i := range docs {
+			docs[i] = text(items[start+i])
+		}
+		resp, err := r.Rerank(ctx, RerankRequest{
+			Query:     query,
+			Documents: docs,
+			TopK:      len(docs), // Scores for every document; cut after merging
+			Model:     opts.Model,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("rerank items %d-%d: %w", start, end-1, err)
+		}
+
+		for _, res := range resp.Results {
+			if res.Index < 0 || res.Index >= len(docs) {
+				return nil, fmt.Errorf("rerank result index %d out of range for batch of %d", res.Index, len(docs))
+			}
+			if res.RelevanceScore < opts.MinScore {
+				continue
+			}
+			i := start + res.Index
+			ranked = append(ranked, RankedItem[T]{Item: items[i], Index: i, Score: res.RelevanceScore})
+		}
+	}
+
+	sort.SliceStable(ranked, func(a, b int) bool {
+		if ranked[a].Score != ranked[b].Score {
+			return ranked[a].Score > ranked[b].Score
+		}
+		return ranked[a].Index < ranked[b].Index
+	})
+	if opts.TopK > 0 && len(ranked) > opts.TopK {
+		ranked = ranked[:opts.TopK]
+	}
+	return ranked, nil
+}
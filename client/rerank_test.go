@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// fakeReranker scores "doc N" as N/100 and rejects batches over max.
+type fakeReranker struct {
+	max     int
+	batches []int
+}
+
+func (f *fakeReranker) Rerank(ctx context.Context, req RerankRequest) (*RerankResponse, error) {
+	if len(req.Documents) > f.max {
+		return nil, fmt.Errorf("API returned status 413")
+	}
+	f.batches = append(f.batches, len(req.Documents))
+	resp := &RerankResponse{}
+	for i := len(req.Documents) - 1; i >= 0; i-- {
+		var n int
+		fmt.Sscanf(req.Documents[i], "doc %d", &n)
+		resp.Results = append(resp.Results, RerankData{Index: i, Document: req.Documents[i], RelevanceScore: float32(n) / 100})
+	}
+	return resp, nil
+}
+
+type rerankRecord struct {
+	ID   string
+	Body string
+}
+
+func TestRerankItemsMergesBatches(t *testing.T) {
+	// Scores are highest in the middle so the best items span batches
+	scores := []int{5, 40, 90, 70, 95, 10, 60, 80, 20}
+	items := make([]rerankRecord, len(scores))
+	for i, s := range scores {
+		items[i] = rerankRecord{ID: fmt.Sprintf("r%d", i), Body: fmt.Sprintf("doc %d", s)}
+	}
+
+	r := &fakeReranker{max: 4}
+	ranked, err := RerankItems(context.Background(), r, "q", items,
+		func(rec rerankRecord) string { return rec.Body },
+		RerankOptions{BatchSize: 4, TopK: 4, MinScore: 0.3})
+	if err != nil {
+		t.Fatalf("RerankItems failed: %v", err)
+	}
+
+	if fmt.Sprint(r.batches) != "[4 4 1]" {
+		t.Errorf("Expected batches [4 4 1], got %v", r.batches)
+	}
+	var ids []string
+	for _, it := range ranked {
+		ids = append(ids, it.Item.ID)
+	}
+	if got := strings.Join(ids, ","); got != "r4,r2,r7,r3" {
+		t.Errorf("Expected r4,r2,r7,r3, got %s", got)
+	}
+	if ranked[0].Index != 4 || ranked[0].Score != 0.95 {
+		t.Errorf("Unexpected top item %+v", ranked[0])
+	}
+}
+
+func TestRerankItemsMinScoreAndErrors(t *testing.T) {
+	items := []string{"doc 10", "doc 20", "doc 30"}
+	ranked, err := RerankItems(context.Background(), &fakeReranker{max: 10}, "q", items,
+		func(s string) string { return s }, RerankOptions{MinScore: 0.2})
+	if err != nil {
+		t.Fatalf("RerankItems failed: %v", err)
+	}
+	if len(ranked) != 2 || ranked[0].Item != "doc 30" || ranked[1].Item != "doc 20" {
+		t.Errorf("Unexpected ranking %+v", ranked)
+	}
+
+	_, err = RerankItems(context.Background(), &fakeReranker{max: 2}, "q", items,
+		func(s string) string { return s }, RerankOptions{BatchSize: 3})
+	if err == nil || !strings.Contains(err.Error(), "items 0-2") {
+		t.Errorf("Expected batch error, got %v", err)
+	}
+}
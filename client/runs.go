@@ -11,20 +11,29 @@ import (
 //
 // Temperature and MaxTokens are optional - if 0, server uses defaults.
 func (c *Client) CreateRun(ctx context.Context, threadID, assistantID string, temperature float32, maxTokens int, enableThinking bool) (*Run, error) {
-	reqBody := map[string]interface{}{
-		"assistant_id":    assistantID,
-		"enable_thinking": enableThinking,
+	body, err := json.Marshal(RunRequest{
+		AssistantID:    assistantID,
+		Temperature:    temperature,
+		MaxTokens:      maxTokens,
+		EnableThinking: enableThinking,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Only include if > 0 (server handles defaults)
-	if temperature > 0 {
-		reqBody["temperature"] = temperature
-	}
-	if maxTokens > 0 {
-		reqBody["max_tokens"] = maxTokens
+	var run Run
+	path := fmt.Sprintf("/threads/%s/runs", threadID)
+	if err := c.doRequest(ctx, "POST", path, body, &run); err != nil {
+		return nil, err
 	}
 
-	body, err := json.Marshal(reqBody)
+	return &run, nil
+}
+
+// CreateRunWithRequest starts an async run using a full RunRequest.
+func (c *Client) CreateRunWithRequest(ctx context.Context, threadID string, req RunRequest) (*Run, error) {
+	req.Stream = false
+	body, err := json.Marshal(req)
 	if err != nil {
 		return nil, err
 	}
@@ -38,6 +47,43 @@ func (c *Client) CreateRun(ctx context.Context, threadID, assistantID string, te
 	return &run, nil
 }
 
+// CreateRunStream starts a run and streams its events as they happen.
+//
+// The caller must Close the returned stream. Use RunStream.Accumulate to
+// collect the final assistant message while handling events.
+//
+// Example:
+//
+//	stream, err := client.CreateRunStream(ctx, threadID, RunRequest{
+//	    AssistantID:    assistantID,
+//	    EnableThinking: true,
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	defer stream.Close()
+//	msg, err := stream.Accumulate(func(ev RunEvent) error {
+//	    if d, ok := ev.(*MessageDeltaEvent); ok {
+//	        fmt.Print(d.Delta.Text())
+//	    }
+//	    return nil
+//	})
+func (c *Client) CreateRunStream(ctx context.Context, threadID string, req RunRequest) (*RunStream, error) {
+	req.Stream = true
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/threads/%s/runs", threadID)
+	respBody, err := c.doStream(ctx, "POST", path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRunStream(respBody), nil
+}
+
 // GetRun retrieves run status.
 func (c *Client) GetRun(ctx context.Context, threadID, runID string) (*Run, error) {
 	var run Run
@@ -53,32 +99,294 @@ func (c *Client) CreateRunSimple(ctx context.Context, threadID, assistantID stri
 	return c.CreateRun(ctx, threadID, assistantID, 0, 0, enableThinking)
 }
 
-// WaitForRun polls until run completes (or fails).
+// SubmitToolOutputs sends client-side tool results for a run in the
+// "requires_action" status, letting the run continue.
+func (c *Client) SubmitToolOutputs(ctx context.Context, threadID, runID string, outputs []ToolOutput) (*Run, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"tool_outputs": outputs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var run Run
+	path := fmt.Sprintf("/threads/%s/runs/%s/submit_tool_outputs", threadID, runID)
+	if err := c.doRequest(ctx, "POST", path, body, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// SubmitToolOutputsStream is like SubmitToolOutputs but streams the rest of
+// the run. The caller must Close the returned stream.
+func (c *Client) SubmitToolOutputsStream(ctx context.Context, threadID, runID string, outputs []ToolOutput) (*RunStream, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"tool_outputs": outputs,
+		"stream":       true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/threads/%s/runs/%s/submit_tool_outputs", threadID, runID)
+	respBody, err := c.doStream(ctx, "POST", path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRunStream(respBody), nil
+}
+
+// CancelRun requests cancellation of a queued or in-progress run.
+func (c *Client) CancelRun(ctx context.Context, threadID, runID string) (*Run, error) {
+	var run Run
+	path := fmt.Sprintf("/threads/%s/runs/%s/cancel", threadID, runID)
+	if err := c.doRequest(ctx, "POST", path, []byte("{}"), &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// WaitOptions configures WaitForRun.
 //
-// Returns the completed run or error. Context can be used to cancel polling.
-func (c *Client) WaitForRun(ctx context.Context, threadID, runID string) (*Run, error) {
-	ticker := time.NewTicker(500 * time.Millisecond)
-	defer ticker.Stop()
+// Zero fields fall back to the defaults from DefaultWaitOptions.
+type WaitOptions struct {
+	// InitialInterval is the delay between the first and second poll.
+	InitialInterval time.Duration
+	// Multiplier grows the interval after each poll (1 = fixed interval).
+	Multiplier float64
+	// MaxInterval caps the interval once backoff has grown it.
+	MaxInterval time.Duration
+	// Timeout bounds the whole wait (0 = only ctx applies).
+	Timeout time.Duration
+	// OnStatus is called with the run whenever its status changes,
+	// including the first status observed.
+	OnStatus func(run *Run)
+	// CancelOnDone issues a best-effort CancelRun when ctx ends before the
+	// run finishes, so abandoned runs stop consuming tokens server-side.
+	CancelOnDone bool
+}
+
+// DefaultWaitOptions returns the polling defaults used by WaitForRun:
+// 500ms initial interval, growing 1.5x per poll up to 5s, no timeout.
+func DefaultWaitOptions() WaitOptions {
+	return WaitOptions{
+		InitialInterval: 500 * time.Millisecond,
+		Multiplier:      1.5,
+		MaxInterval:     5 * time.Second,
+	}
+}
 
+// WaitOption configures WaitForRun.
+type WaitOption func(*WaitOptions)
+
+// WithWaitOptions sets every non-zero field of opts at once. Zero fields
+// keep their current value, so options applied earlier (for example
+// WithCancelOnDone) are not lost.
+func WithWaitOptions(opts WaitOptions) WaitOption {
+	return func(o *WaitOptions) {
+		if opts.InitialInterval != 0 {
+			o.InitialInterval = opts.InitialInterval
+		}
+		if opts.Multiplier != 0 {
+			o.Multiplier = opts.Multiplier
+		}
+		if opts.MaxInterval != 0 {
+			o.MaxInterval = opts.MaxInterval
+		}
+		if opts.Timeout != 0 {
+			o.Timeout = opts.Timeout
+		}
+		if opts.OnStatus != nil {
+			o.OnStatus = opts.OnStatus
+		}
+		if opts.CancelOnDone {
+			o.CancelOnDone = true
+		}
+	}
+}
+
+// WithPollInterval sets the initial and maximum polling intervals.
+func WithPollInterval(initial, max time.Duration) WaitOption {
+	return func(o *WaitOptions) {
+		o.InitialInterval = initial
+		o.MaxInterval = max
+	}
+}
+
+// WithBackoff sets the polling interval multiplier (1 disables backoff).
+func WithBackoff(multiplier float64) WaitOption {
+	return func(o *WaitOptions) {
+		o.Multiplier = multiplier
+	}
+}
+
+// WithWaitTimeout bounds the total time spent waiting for the run.
+func WithWaitTimeout(timeout time.Duration) WaitOption {
+	return func(o *WaitOptions) {
+		o.Timeout = timeout
+	}
+}
+
+// WithOnStatus registers a callback fired on each run status change
+// (queued -> in_progress -> completed, ...).
+func WithOnStatus(fn func(run *Run)) WaitOption {
+	return func(o *WaitOptions) {
+		o.OnStatus = fn
+	}
+}
+
+// WithCancelOnDone cancels the run on the server if ctx ends while waiting.
+//
+// The cancel request uses a short detached context (see CancelTimeout), and
+// its outcome is reported through the returned *RunAbortError.
+func WithCancelOnDone() WaitOption {
+	return func(o *WaitOptions) {
+		o.CancelOnDone = true
+	}
+}
+
+// CancelTimeout bounds the detached cancel request issued by WithCancelOnDone.
+var CancelTimeout = 5 * time.Second
+
+// WaitForRun polls until run completes (or fails, is cancelled, expires
+// or ends incomplete).
+//
+// A run in the "requires_action" status is returned without error - submit
+// tool outputs with SubmitToolOutputs and wait again, or use RunDriver.
+//
+// The first poll happens immediately, then the interval backs off as
+// configured by opts (see DefaultWaitOptions). Returns the completed run or
+// error. Context can be used to cancel polling. With WithCancelOnDone, a
+// cancelled context or expired Timeout also cancels the run itself.
+func (c *Client) WaitForRun(ctx context.Context, threadID, runID string, opts ...WaitOption) (*Run, error) {
+	o := DefaultWaitOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	o.applyDefaults()
+
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+
+	interval := o.InitialInterval
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	var lastStatus string
 	for {
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-ticker.C:
-			run, err := c.GetRun(ctx, threadID, runID)
-			if err != nil {
-				return nil, err
+			return nil, c.abortRun(ctx, threadID, runID, o)
+		case <-timer.C:
+		}
+
+		run, err := c.GetRun(ctx, threadID, runID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, c.abortRun(ctx, threadID, runID, o)
 			}
+			return nil, err
+		}
 
-			switch run.Status {
-			case "completed":
-				return run, nil
-			case "failed":
-				return run, fmt.Errorf("run failed")
-			case "cancelled":
-				return run, fmt.Errorf("run cancelled")
-			// Continue polling for "queued" or "in_progress"
+		if run.Status != lastStatus {
+			lastStatus = run.Status
+			if o.OnStatus != nil {
+				o.OnStatus(run)
 			}
 		}
+
+		if err := runStatusError(run.Status); err != nil {
+			return run, err
+		}
+		if runStopped(run.Status) {
+			return run, nil
+		}
+
+		// Continue polling for "queued" or "in_progress"
+		timer.Reset(interval)
+		interval = o.nextInterval(interval)
+	}
+}
+
+// runStatusError returns the error for a run that failed, was cancelled,
+// expired or ended incomplete, and nil for any other status.
+func runStatusError(status string) error {
+	switch status {
+	case "failed", "cancelled", "expired", "incomplete":
+		return fmt.Errorf("run %s", status)
+	}
+	return nil
+}
+
+// runStopped reports whether a run with this status will not progress
+// without the caller: it finished, or it waits for tool outputs.
+func runStopped(status string) bool {
+	switch status {
+	case "completed", "requires_action", "failed", "cancelled", "expired", "incomplete":
+		return true
+	}
+	return false
+}
+
+// applyDefaults fills zero polling fields with DefaultWaitOptions values.
+func (o *WaitOptions) applyDefaults() {
+	def := DefaultWaitOptions()
+	if o.InitialInterval <= 0 {
+		o.InitialInterval = def.InitialInterval
+	}
+	if o.Multiplier == 0 {
+		o.Multiplier = def.Multiplier
+	} else if o.Multiplier < 1 {
+		o.Multiplier = 1
+	}
+	if o.MaxInterval <= 0 {
+		o.MaxInterval = def.MaxInterval
+	}
+	if o.MaxInterval < o.InitialInterval {
+		o.MaxInterval = o.InitialInterval
+	}
+}
+
+// nextInterval applies backoff to the current polling interval.
+func (o *WaitOptions) nextInterval(cur time.Duration) time.Duration {
+	next := time.Duration(float64(cur) * o.Multiplier)
+	if next > o.MaxInterval {
+		return o.MaxInterval
+	}
+	return next
+}
+
+// CreateRunAndWait starts a run with defaults and waits for it to finish.
+//
+// Pass WithCancelOnDone to cancel the run if ctx ends first.
+func (c *Client) CreateRunAndWait(ctx context.Context, threadID, assistantID string, enableThinking bool, opts ...WaitOption) (*Run, error) {
+	run, err := c.CreateRunSimple(ctx, threadID, assistantID, enableThinking)
+	if err != nil {
+		return nil, err
+	}
+	return c.WaitForRun(ctx, threadID, run.ID, opts...)
+}
+
+// abortRun returns the error for a wait that ended because ctx is done,
+// cancelling the run first when requested.
+func (c *Client) abortRun(ctx context.Context, threadID, runID string, o WaitOptions) error {
+	if !o.CancelOnDone {
+		return ctx.Err()
+	}
+
+	// ctx is already done - use a detached context for the cancel call
+	cancelCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), CancelTimeout)
+	defer cancel()
+
+	_, cancelErr := c.CancelRun(cancelCtx, threadID, runID)
+	return &RunAbortError{
+		ThreadID:  threadID,
+		RunID:     runID,
+		Err:       ctx.Err(),
+		CancelErr: cancelErr,
 	}
 }
@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWaitForRunCancelOnDone(t *testing.T) {
+	var cancelled atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/cancel") {
+			cancelled.Store(true)
+			w.Write([]byte(`{"id":"run_1","status":"cancelled"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"run_1","status":"in_progress"}`))
+	}))
+	defer srv.Close()
+
+	c := New("test-key", srv.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 700*time.Millisecond)
+	defer cancel()
+
+	_, err := c.WaitForRun(ctx, "thread_1", "run_1", WithCancelOnDone())
+
+	var abortErr *RunAbortError
+	if !errors.As(err, &abortErr) {
+		t.Fatalf("Expected *RunAbortError, got %v", err)
+	}
+	if !abortErr.Cancelled() {
+		t.Errorf("Expected cancel to succeed, got %v", abortErr.CancelErr)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected error to wrap context.DeadlineExceeded, got %v", err)
+	}
+	if !cancelled.Load() {
+		t.Error("Cancel endpoint was not called")
+	}
+}
+
+func TestWaitForRunWithoutCancelOnDone(t *testing.T) {
+	var cancelled atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/cancel") {
+			cancelled.Store(true)
+		}
+		w.Write([]byte(`{"id":"run_1","status":"queued"}`))
+	}))
+	defer srv.Close()
+
+	c := New("test-key", srv.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 700*time.Millisecond)
+	defer cancel()
+
+	_, err := c.WaitForRun(ctx, "thread_1", "run_1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if cancelled.Load() {
+		t.Error("Cancel endpoint called without WithCancelOnDone")
+	}
+}
+
+func TestWaitForRunPollsImmediatelyAndReportsStatus(t *testing.T) {
+	statuses := []string{"queued", "in_progress", "in_progress", "completed"}
+	var polls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(polls.Add(1)) - 1
+		if n >= len(statuses) {
+			n = len(statuses) - 1
+		}
+		w.Write([]byte(`{"id":"run_1","status":"` + statuses[n] + `"}`))
+	}))
+	defer srv.Close()
+
+	c := New("test-key", srv.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var seen []string
+	start := time.Now()
+	run, err := c.WaitForRun(ctx, "thread_1", "run_1",
+		WithPollInterval(10*time.Millisecond, 40*time.Millisecond),
+		WithBackoff(2),
+		WithOnStatus(func(run *Run) { seen = append(seen, run.Status) }),
+	)
+	if err != nil {
+		t.Fatalf("WaitForRun failed: %v", err)
+	}
+	if run.Status != "completed" {
+		t.Errorf("Expected status 'completed', got %s", run.Status)
+	}
+	if got := strings.Join(seen, ","); got != "queued,in_progress,completed" {
+		t.Errorf("Unexpected status transitions: %s", got)
+	}
+	// 10ms + 20ms + 40ms of backoff, well under the old 4x500ms ticker
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Polling took too long: %v", elapsed)
+	}
+}
+
+func TestWaitForRunTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"run_1","status":"in_progress"}`))
+	}))
+	defer srv.Close()
+
+	c := New("test-key", srv.URL)
+	_, err := c.WaitForRun(context.Background(), "thread_1", "run_1",
+		WithPollInterval(10*time.Millisecond, 10*time.Millisecond),
+		WithWaitTimeout(100*time.Millisecond),
+	)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestWaitForRunTerminalStatuses(t *testing.T) {
+	for _, status := range []string{"expired", "incomplete"} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"id":"run_1","status":"` + status + `"}`))
+		}))
+
+		c := New("test-key", srv.URL)
+		run, err := c.WaitForRun(context.Background(), "thread_1", "run_1", WithWaitTimeout(time.Second))
+		srv.Close()
+		if err == nil || !strings.Contains(err.Error(), status) || errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("%s: expected a terminal error, got %v", status, err)
+		}
+		if run == nil || run.Status != status {
+			t.Errorf("%s: expected the run to be returned, got %+v", status, run)
+		}
+	}
+}
+
+func TestWaitForRunWithWaitOptionsMerges(t *testing.T) {
+	o := DefaultWaitOptions()
+	for _, opt := range []WaitOption{
+		WithCancelOnDone(),
+		WithOnStatus(func(*Run) {}),
+		WithWaitOptions(WaitOptions{Timeout: time.Minute}),
+	} {
+		opt(&o)
+	}
+	if !o.CancelOnDone || o.OnStatus == nil || o.Timeout != time.Minute {
+		t.Errorf("Earlier options lost: %+v", o)
+	}
+	if o.InitialInterval != DefaultWaitOptions().InitialInterval {
+		t.Errorf("Zero field overwrote the default: %v", o.InitialInterval)
+	}
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ListRunSteps retrieves all steps of a run in execution order.
+//
+// Use it to see which tools ran, with what arguments, how long they took
+// and whether they failed. limit is the page size (default 20); pages are
+// followed until the server reports no more steps.
+func (c *Client) ListRunSteps(ctx context.Context, threadID, runID string, limit int) ([]RunStep, error) {
+	if limit == 0 {
+		limit = 20
+	}
+
+	var steps []RunStep
+	after := ""
+	for {
+		var resp struct {
+			Object  string    `json:"object"`
+			Data    []RunStep `json:"data"`
+			FirstID string    `json:"first_id,omitempty"`
+			LastID  string    `json:"last_id,omitempty"`
+			HasMore bool      `json:"has_more"`
+		}
+
+		path := fmt.Sprintf("/threads/%s/runs/%s/steps?limit=%d&order=asc", threadID, runID, limit)
+		if after != "" {
+			path += "&after=" + url.QueryEscape(after)
+		}
+		if err := c.doRequest(ctx, "GET", path, nil, &resp); err != nil {
+			return nil, err
+		}
+		steps = append(steps, resp.Data...)
+
+		if !resp.HasMore || len(resp.Data) == 0 {
+			return steps, nil
+		}
+		after = resp.Data[len(resp.Data)-1].ID
+	}
+}
+
+// GetRunStep retrieves a single run step.
+func (c *Client) GetRunStep(ctx context.Context, threadID, runID, stepID string) (*RunStep, error) {
+	var step RunStep
+	path := fmt.Sprintf("/threads/%s/runs/%s/steps/%s", threadID, runID, stepID)
+	if err := c.doRequest(ctx, "GET", path, nil, &step); err != nil {
+		return nil, err
+	}
+	return &step, nil
+}
+
+// Duration returns how long the step took, or 0 if it has not finished.
+func (s *RunStep) Duration() time.Duration {
+	var end *int64
+	switch {
+	case s.CompletedAt != nil:
+		end = s.CompletedAt
+	case s.FailedAt != nil:
+		end = s.FailedAt
+	case s.CancelledAt != nil:
+		end = s.CancelledAt
+	default:
+		return 0
+	}
+	return time.Duration(*end-s.CreatedAt) * time.Second
+}
+
+// Name returns the function or Pixi tool name of the call.
+func (tc *RunStepToolCall) Name() string {
+	switch {
+	case tc.Function != nil:
+		return tc.Function.Name
+	case tc.Pixi != nil:
+		return tc.Pixi.Name
+	}
+	return ""
+}
+
+// Failed reports whether the tool call returned an error.
+func (tc *RunStepToolCall) Failed() bool {
+	return tc.Pixi != nil && tc.Pixi.Error != nil
+}
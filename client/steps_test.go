@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListRunSteps(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.String()
+		io.WriteString(w, `{"object":"list","data":[
+			{"id":"step_1","run_id":"run_1","type":"tool_calls","status":"failed","created_at":100,"failed_at":103,
+			 "step_details":{"type":"tool_calls","tool_calls":[
+				{"id":"call_1","type":"pixi","pixi":{"name":"Fetch","input":{"url":"https://example.com"},"error":"timeout","duration_ms":3000}},
+				{"id":"call_2","type":"function","function":{"name":"get_weather","arguments":"{}","output":"{\"temp_c\":21}"}}]},
+			 "last_error":{"code":"tool_error","message":"Fetch timed out"}},
+			{"id":"step_2","run_id":"run_1","type":"message_creation","status":"completed","created_at":103,"completed_at":105,
+			 "step_details":{"type":"message_creation","message_creation":{"message_id":"msg_1"}},
+			 "usage":{"prompt_tokens":10,"completion_tokens":5,"total_tokens":15}}]}`)
+	}))
+	defer srv.Close()
+
+	c := New("test-key", srv.URL)
+	steps, err := c.ListRunSteps(context.Background(), "thread_1", "run_1", 0)
+	if err != nil {
+		t.Fatalf("ListRunSteps failed: %v", err)
+	}
+	if gotPath != "/threads/thread_1/runs/run_1/steps?limit=20&order=asc" {
+		t.Errorf("Unexpected request path: %s", gotPath)
+	}
+	if len(steps) != 2 {
+		t.Fatalf("Expected 2 steps, got %d", len(steps))
+	}
+
+	calls := steps[0].StepDetails.ToolCalls
+	if len(calls) != 2 {
+		t.Fatalf("Expected 2 tool calls, got %d", len(calls))
+	}
+	if calls[0].Name() != "Fetch" || !calls[0].Failed() {
+		t.Errorf("Expected failed Fetch call, got %+v", calls[0])
+	}
+	if string(calls[0].Pixi.Input) != `{"url":"https://example.com"}` {
+		t.Errorf("Unexpected tool input: %s", calls[0].Pixi.Input)
+	}
+	if calls[1].Name() != "get_weather" || calls[1].Failed() {
+		t.Errorf("Expected successful get_weather call, got %+v", calls[1])
+	}
+	if d := steps[0].Duration(); d != 3*time.Second {
+		t.Errorf("Expected 3s duration, got %v", d)
+	}
+	if steps[1].StepDetails.MessageCreation.MessageID != "msg_1" {
+		t.Errorf("Unexpected message creation: %+v", steps[1].StepDetails.MessageCreation)
+	}
+	if steps[1].Usage.TotalTokens != 15 {
+		t.Errorf("Expected 15 total tokens, got %d", steps[1].Usage.TotalTokens)
+	}
+}
+
+func TestListRunStepsPaginates(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.String())
+		if r.URL.Query().Get("after") == "" {
+			io.WriteString(w, `{"object":"list","data":[{"id":"step_1"},{"id":"step_2"}],"has_more":true}`)
+			return
+		}
+		io.WriteString(w, `{"object":"list","data":[{"id":"step_3"}],"has_more":false}`)
+	}))
+	defer srv.Close()
+
+	c := New("test-key", srv.URL)
+	steps, err := c.ListRunSteps(context.Background(), "thread_1", "run_1", 2)
+	if err != nil {
+		t.Fatalf("ListRunSteps failed: %v", err)
+	}
+	if len(steps) != 3 || steps[2].ID != "step_3" {
+		t.Errorf("Expected 3 steps across pages, got %+v", steps)
+	}
+	if len(paths) != 2 || paths[1] != "/threads/thread_1/runs/run_1/steps?limit=2&order=asc&after=step_2" {
+		t.Errorf("Unexpected requests: %v", paths)
+	}
+}
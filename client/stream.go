@@ -0,0 +1,262 @@
+package client
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// sseEvent is a single server-sent event.
+type sseEvent struct {
+	Event string
+	Data  string
+}
+
+// sseReader parses a text/event-stream body.
+type sseReader struct {
+	r *bufio.Reader
+}
+
+func newSSEReader(r io.Reader) *sseReader {
+	return &sseReader{r: bufio.NewReader(r)}
+}
+
+// Next returns the next event with data, or io.EOF when the stream ends.
+func (s *sseReader) Next() (*sseEvent, error) {
+	var ev sseEvent
+	var data []string
+
+	for {
+		line, err := s.r.ReadString('\n')
+		if err != nil && line == "" {
+			// Dispatch a final event that was not followed by a blank line
+			if errors.Is(err, io.EOF) && len(data) > 0 {
+				ev.Data = strings.Join(data, "\n")
+				return &ev, nil
+			}
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			if len(data) > 0 {
+				ev.Data = strings.Join(data, "\n")
+				return &ev, nil
+			}
+			ev = sseEvent{} // Event without data - ignore
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue // Comment / keep-alive
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			ev.Event = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+}
+
+// RunStream is a stream of run events returned by CreateRunStream.
+type RunStream struct {
+	body io.ReadCloser
+	sse  *sseReader
+}
+
+func newRunStream(body io.ReadCloser) *RunStream {
+	return &RunStream{body: body, sse: newSSEReader(body)}
+}
+
+// Recv returns the next event. It returns io.EOF once the stream is done.
+func (s *RunStream) Recv() (RunEvent, error) {
+	raw, err := s.sse.Next()
+	if err != nil {
+		return nil, err
+	}
+	if raw.Data == "[DONE]" {
+		return nil, io.EOF
+	}
+	return decodeRunEvent(raw.Event, []byte(raw.Data))
+}
+
+// Close releases the underlying connection.
+func (s *RunStream) Close() error {
+	return s.body.Close()
+}
+
+// Accumulate reads the stream to the end, passing each event to fn (which
+// may be nil), and returns the final assistant message.
+//
+// Returns an error if fn fails, the stream reports an error, the run fails,
+// is cancelled, expires or ends incomplete, or the stream ends before the
+// run completes or requires action (ErrStreamEnded). The partial message
+// is returned with every error.
+func (s *RunStream) Accumulate(fn func(RunEvent) error) (*ThreadMessage, error) {
+	var acc MessageAccumulator
+	for {
+		ev, err := s.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return acc.Message(), err
+		}
+
+		acc.Add(ev)
+		if fn != nil {
+			if err := fn(ev); err != nil {
+				return acc.Message(), err
+			}
+		}
+		if e, ok := ev.(*ErrorEvent); ok {
+			return acc.Message(), &e.APIError
+		}
+	}
+
+	run := acc.Run()
+	if run == nil || !runStopped(run.Status) {
+		return acc.Message(), ErrStreamEnded
+	}
+	return acc.Message(), runStatusError(run.Status)
+}
+
+// decodeRunEvent maps an SSE event name to its typed RunEvent.
+func decodeRunEvent(name string, data []byte) (RunEvent, error) {
+	var ev RunEvent
+	switch {
+	case strings.HasPrefix(name, "thread.run.step."):
+		ev = &RunStepEvent{Event: name}
+	case strings.HasPrefix(name, "thread.run."):
+		ev = &RunStatusEvent{Event: name}
+	case name == "thread.message.delta":
+		ev = &MessageDeltaEvent{Event: name}
+	case name == "thread.message.source":
+		ev = &SourceEvent{Event: name}
+	case name == "thread.message.media":
+		ev = &MediaEvent{Event: name}
+	case name == "thread.message.code":
+		ev = &CodeEvent{Event: name}
+	case strings.HasPrefix(name, "thread.message."):
+		ev = &MessageEvent{Event: name}
+	case strings.HasPrefix(name, "thread.tool."):
+		ev = &ToolEvent{Event: name}
+	case name == "error":
+		ev = &ErrorEvent{Event: name}
+	default:
+		return &UnknownEvent{Event: name, Data: data}, nil
+	}
+
+	if err := json.Unmarshal(data, ev); err != nil {
+		return nil, fmt.Errorf("failed to parse %s event: %w", name, err)
+	}
+	return ev, nil
+}
+
+// MessageAccumulator builds the final assistant message from run events.
+//
+// The zero value is ready to use.
+type MessageAccumulator struct {
+	msg *ThreadMessage
+	run *Run
+}
+
+// Add applies an event to the message being built.
+func (a *MessageAccumulator) Add(ev RunEvent) {
+	switch e := ev.(type) {
+	case *RunStatusEvent:
+		run := e.Run
+		a.run = &run
+		if run.Message != nil {
+			// Completed runs carry the authoritative message
+			msg := *run.Message
+			a.msg = &msg
+		}
+	case *MessageEvent:
+		if e.Role != "" && e.Role != "assistant" {
+			return
+		}
+		msg := e.ThreadMessage
+		a.msg = &msg
+	case *MessageDeltaEvent:
+		msg := a.message(e.ID)
+		msg.ReasoningContent += e.Delta.ReasoningContent
+		for _, part := range e.Delta.Content {
+			if part.Index < 0 {
+				continue // Malformed delta
+			}
+			for len(msg.Content) <= part.Index {
+				msg.Content = append(msg.Content, MessageContent{Type: "text"})
+			}
+			if part.Type != "" {
+				msg.Content[part.Index].Type = part.Type
+			}
+			if part.Text != nil {
+				msg.Content[part.Index].Text.Value += part.Text.Value
+				msg.Content[part.Index].Text.Annotations = append(msg.Content[part.Index].Text.Annotations, part.Text.Annotations...)
+			}
+		}
+	case *SourceEvent:
+		msg := a.message(e.MessageID)
+		for _, src := range msg.Sources {
+			if src.ID == e.MessageSource.ID {
+				return
+			}
+		}
+		msg.Sources = append(msg.Sources, e.MessageSource)
+	case *MediaEvent:
+		msg := a.message(e.MessageID)
+		for _, media := range msg.Media {
+			if media.ID == e.MessageMedia.ID {
+				return
+			}
+		}
+		msg.Media = append(msg.Media, e.MessageMedia)
+	case *CodeEvent:
+		msg := a.message(e.MessageID)
+		for _, code := range msg.Code {
+			if code.ID == e.MessageCode.ID {
+				return
+			}
+		}
+		msg.Code = append(msg.Code, e.MessageCode)
+	}
+}
+
+// Message returns the message built so far (nil if no message events yet).
+func (a *MessageAccumulator) Message() *ThreadMessage {
+	return a.msg
+}
+
+// Run returns the latest run state seen on the stream.
+func (a *MessageAccumulator) Run() *Run {
+	return a.run
+}
+
+// message returns the message being built, starting one if needed.
+func (a *MessageAccumulator) message(id string) *ThreadMessage {
+	if a.msg == nil {
+		a.msg = &ThreadMessage{ID: id, Object: "thread.message", Role: "assistant"}
+		if a.run != nil {
+			a.msg.ThreadID = a.run.ThreadID
+		}
+	}
+	return a.msg
+}
+
+// Text joins the text fragments in the delta.
+func (d MessageDelta) Text() string {
+	var b strings.Builder
+	for _, part := range d.Content {
+		if part.Text != nil {
+			b.WriteString(part.Text.Value)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testRunStream = `event: thread.run.created
+data: {"id":"run_1","thread_id":"thread_1","status":"queued"}
+
+event: thread.run.in_progress
+data: {"id":"run_1","thread_id":"thread_1","status":"in_progress"}
+
+: keep-alive
+
+event: thread.message.delta
+data: {"id":"msg_1","object":"thread.message.delta","delta":{"reasoning_content":"Paris is "}}
+
+event: thread.message.delta
+data: {"id":"msg_1","object":"thread.message.delta","delta":{"reasoning_content":"the capital.","content":[{"index":0,"type":"text","text":{"value":"Par"}}]}}
+
+event: thread.tool.started
+data: {"id":"tool_1","run_id":"run_1","tool_name":"WebSearch","arguments":"{\"q\":\"capital of France\"}","status":"in_progress"}
+
+event: thread.tool.completed
+data: {"id":"tool_1","run_id":"run_1","tool_name":"WebSearch","status":"completed","duration_ms":420}
+
+event: thread.message.source
+data: {"message_id":"msg_1","id":"src_1","tool_name":"WebSearch","url":"https://example.com"}
+
+event: thread.message.delta
+data: {"id":"msg_1","object":"thread.message.delta","delta":{"content":[{"index":0,"text":{"value":"is"}}]}}
+
+event: thread.run.completed
+data: {"id":"run_1","thread_id":"thread_1","status":"completed"}
+
+data: [DONE]
+
+`
+
+func TestCreateRunStreamAccumulate(t *testing.T) {
+	var gotReq RunRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&gotReq)
+		w.Header().Set("Content-Type", "text/event-stream")
+		io.WriteString(w, testRunStream)
+	}))
+	defer srv.Close()
+
+	c := New("test-key", srv.URL)
+	stream, err := c.CreateRunStream(context.Background(), "thread_1", RunRequest{AssistantID: "asst_1"})
+	if err != nil {
+		t.Fatalf("CreateRunStream failed: %v", err)
+	}
+	defer stream.Close()
+
+	if !gotReq.Stream {
+		t.Error("Request did not set stream=true")
+	}
+
+	var types []string
+	msg, err := stream.Accumulate(func(ev RunEvent) error {
+		types = append(types, ev.EventType())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Accumulate failed: %v", err)
+	}
+
+	if len(types) != 9 {
+		t.Errorf("Expected 9 events, got %d: %v", len(types), types)
+	}
+	if msg == nil || len(msg.Content) != 1 {
+		t.Fatalf("Unexpected message: %+v", msg)
+	}
+	if got := msg.Content[0].Text.Value; got != "Paris" {
+		t.Errorf("Expected content 'Paris', got %q", got)
+	}
+	if msg.ReasoningContent != "Paris is the capital." {
+		t.Errorf("Unexpected reasoning: %q", msg.ReasoningContent)
+	}
+	if len(msg.Sources) != 1 || msg.Sources[0].ToolName != "WebSearch" {
+		t.Errorf("Unexpected sources: %+v", msg.Sources)
+	}
+	if msg.ThreadID != "thread_1" {
+		t.Errorf("Expected thread_1, got %q", msg.ThreadID)
+	}
+}
+
+func TestCreateRunStreamFailedRun(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "event: thread.run.failed\ndata: {\"id\":\"run_1\",\"status\":\"failed\"}\n\n")
+	}))
+	defer srv.Close()
+
+	c := New("test-key", srv.URL)
+	stream, err := c.CreateRunStream(context.Background(), "thread_1", RunRequest{AssistantID: "asst_1"})
+	if err != nil {
+		t.Fatalf("CreateRunStream failed: %v", err)
+	}
+	defer stream.Close()
+
+	if _, err := stream.Accumulate(nil); err == nil {
+		t.Error("Expected error for failed run, got nil")
+	}
+}
+
+func TestStreamAccumulatorSkipsNegativeIndex(t *testing.T) {
+	var acc MessageAccumulator
+	acc.Add(&MessageDeltaEvent{ID: "msg_1", Delta: MessageDelta{Content: []MessageDeltaContent{
+		{Index: -1, Type: "text", Text: &MessageContentText{Value: "bad"}},
+		{Index: 0, Type: "text", Text: &MessageContentText{Value: "good"}},
+	}}})
+
+	msg := acc.Message()
+	if msg == nil || len(msg.Content) != 1 || msg.Content[0].Text.Value != "good" {
+		t.Errorf("Expected only the valid fragment, got %+v", msg)
+	}
+}
+
+func TestCreateRunStreamUnfinishedRun(t *testing.T) {
+	for name, tc := range map[string]struct {
+		body string
+		want string
+	}{
+		"truncated": {
+			body: "event: thread.run.in_progress\ndata: {\"id\":\"run_1\",\"status\":\"in_progress\"}\n\n" +
+				"event: thread.message.delta\ndata: {\"id\":\"msg_1\",\"delta\":{\"content\":[{\"index\":0,\"type\":\"text\",\"text\":{\"value\":\"Hal\"}}]}}\n\n",
+			want: ErrStreamEnded.Error(),
+		},
+		"expired":    {body: "event: thread.run.expired\ndata: {\"id\":\"run_1\",\"status\":\"expired\"}\n\n", want: "run expired"},
+		"incomplete": {body: "event: thread.run.incomplete\ndata: {\"id\":\"run_1\",\"status\":\"incomplete\"}\n\n", want: "run incomplete"},
+	} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, tc.body)
+		}))
+
+		stream, err := New("test-key", srv.URL).CreateRunStream(context.Background(), "thread_1", RunRequest{AssistantID: "asst_1"})
+		if err != nil {
+			t.Fatalf("%s: CreateRunStream failed: %v", name, err)
+		}
+		msg, err := stream.Accumulate(nil)
+		stream.Close()
+		srv.Close()
+
+		if err == nil || err.Error() != tc.want {
+			t.Errorf("%s: expected %q, got %v", name, tc.want, err)
+		}
+		if name == "truncated" && (msg == nil || msg.Content[0].Text.Value != "Hal") {
+			t.Errorf("Expected the partial message with the error, got %+v", msg)
+		}
+	}
+}
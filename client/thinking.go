@@ -0,0 +1,96 @@
+package client
+
+import "strings"
+
+const (
+	thinkOpen  = "<think>"
+	thinkClose = "</think>"
+)
+
+// SplitThinking separates inline <think>...</think> reasoning from the
+// answer in model output.
+//
+// It handles several blocks, an unterminated <think> from truncated output
+// (everything after it is reasoning) and a bare </think> without an opening
+// tag, which some templates emit (everything before it is reasoning). Both
+// parts are trimmed; content without tags is returned as the answer.
+func SplitThinking(content string) (reasoning, answer string) {
+	var thoughts, parts []string
+
+	rest := content
+	// Bare closing tag before any opening tag
+	if end := strings.Index(rest, thinkClose); end >= 0 {
+		if start := strings.Index(rest, thinkOpen); start < 0 || end < start {
+			thoughts = append(thoughts, rest[:end])
+			rest = rest[end+len(thinkClose):]
+		}
+	}
+
+	for {
+		start := strings.Index(rest, thinkOpen)
+		if start < 0 {
+			parts = append(parts, rest)
+			break
+		}
+		parts = append(parts, rest[:start])
+		rest = rest[start+len(thinkOpen):]
+
+		end := strings.Index(rest, thinkClose)
+		if end < 0 {
+			thoughts = append(thoughts, rest) // Truncated mid-thought
+			break
+		}
+		thoughts = append(thoughts, rest[:end])
+		rest = rest[end+len(thinkClose):]
+	}
+
+	return joinTrimmed(thoughts), joinTrimmed(parts)
+}
+
+// HasThinkingTags reports whether content contains inline think tags.
+func HasThinkingTags(content string) bool {
+	return strings.Contains(content, thinkOpen) || strings.Contains(content, thinkClose)
+}
+
+func joinTrimmed(parts []string) string {
+	var kept []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, "\n\n")
+}
+
+// replayMessages prepares history for sending: unless keep is set,
+// reasoning from earlier assistant turns is removed.
+func replayMessages(messages []Message, keep bool) []Message {
+	if keep {
+		return messages
+	}
+	out := make([]Message, len(messages))
+	for i, m := range messages {
+		m.ReasoningContent = ""
+		if m.Role == "assistant" && HasThinkingTags(m.Content) {
+			_, m.Content = SplitThinking(m.Content)
+		}
+		out[i] = m
+	}
+	return out
+}
+
+// splitChoiceThinking fills ReasoningContent from inline think tags when the
+// server did not split it out.
+func splitChoiceThinking(choice *ChatCompletionChoice) {
+	if !HasThinkingTags(choice.Message.Content) {
+		return
+	}
+	reasoning, answer := SplitThinking(choice.Message.Content)
+	choice.Message.Content = answer
+	if choice.ReasoningContent == "" {
+		choice.ReasoningContent = reasoning
+	}
+	if choice.Message.ReasoningContent == "" {
+		choice.Message.ReasoningContent = reasoning
+	}
+}
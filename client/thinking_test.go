@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSplitThinking(t *testing.T) {
+	tests := []struct {
+		name, in, reasoning, answer string
+	}{
+		{"plain", "Hello!", "", "Hello!"},
+		{"block", "<think>Greet back.</think>\n\nHello!", "Greet back.", "Hello!"},
+		{"multiple", "<think>a</think>one<think>b</think>two", "a\n\nb", "one\n\ntwo"},
+		{"unterminated", "<think>Let me count: 1, 2,", "Let me count: 1, 2,", ""},
+		{"bare close", "Plan it.\n</think>\nDone.", "Plan it.", "Done."},
+		{"empty", "<think></think>Hi", "", "Hi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reasoning, answer := SplitThinking(tt.in)
+			if reasoning != tt.reasoning || answer != tt.answer {
+				t.Errorf("SplitThinking(%q) = %q, %q; want %q, %q", tt.in, reasoning, answer, tt.reasoning, tt.answer)
+			}
+		})
+	}
+}
+
+func TestChatCompletionThinkingFallbackAndReplay(t *testing.T) {
+	var sent ChatCompletionRequest
+	var raw map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &sent)
+		json.Unmarshal(body, &raw)
+		io.WriteString(w, `{"choices":[{"message":{"role":"assistant","content":"<think>Add them.</think>4"}}]}`)
+	}))
+	defer srv.Close()
+	c := New("test-key", srv.URL, WithRetryMax(0))
+
+	history := []Message{
+		{Role: "system", Content: "Be brief."},
+		{Role: "user", Content: "1+1?"},
+		{Role: "assistant", Content: "<think>easy</think>2", ReasoningContent: "easy"},
+		{Role: "user", Content: "2+2?"},
+	}
+	resp, err := c.CreateChatCompletion(context.Background(), ChatCompletionRequest{
+		Messages:        history,
+		ReasoningEffort: ReasoningHigh,
+		ThinkingBudget:  1024,
+	})
+	if err != nil {
+		t.Fatalf("CreateChatCompletion failed: %v", err)
+	}
+
+	if raw["reasoning_effort"] != "high" || raw["thinking_budget"] != float64(1024) {
+		t.Errorf("Reasoning controls not sent: %v", raw)
+	}
+	if _, ok := raw["KeepReasoning"]; ok {
+		t.Error("KeepReasoning must not be sent")
+	}
+	if got := sent.Messages[2]; got.Content != "2" || got.ReasoningContent != "" {
+		t.Errorf("Reasoning not stripped from history: %+v", got)
+	}
+	if history[2].ReasoningContent != "easy" {
+		t.Error("Caller's history was modified")
+	}
+
+	content, _ := resp.Content()
+	reasoning, _ := resp.Reasoning()
+	if content != "4" || reasoning != "Add them." {
+		t.Errorf("Unexpected split: content %q, reasoning %q", content, reasoning)
+	}
+
+	if _, err := c.CreateChatCompletion(context.Background(), ChatCompletionRequest{
+		Messages: history, KeepReasoning: true,
+	}); err != nil {
+		t.Fatalf("CreateChatCompletion failed: %v", err)
+	}
+	if got := sent.Messages[2]; got.ReasoningContent != "easy" {
+		t.Errorf("Reasoning should be kept: %+v", got)
+	}
+}
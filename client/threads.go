@@ -2,6 +2,8 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 )
 
 // CreateThread creates a new conversation thread.
@@ -13,6 +15,22 @@ func (c *Client) CreateThread(ctx context.Context) (*Thread, error) {
 	return &thread, nil
 }
 
+// CreateThreadWithMetadata creates a new thread carrying metadata.
+func (c *Client) CreateThreadWithMetadata(ctx context.Context, metadata map[string]string) (*Thread, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"metadata": metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var thread Thread
+	if err := c.doRequest(ctx, "POST", "/threads", body, &thread); err != nil {
+		return nil, err
+	}
+	return &thread, nil
+}
+
 // GetThread retrieves a thread by ID.
 func (c *Client) GetThread(ctx context.Context, threadID string) (*Thread, error) {
 	var thread Thread
@@ -38,3 +56,61 @@ func (c *Client) ListThreads(ctx context.Context) ([]Thread, error) {
 func (c *Client) DeleteThread(ctx context.Context, threadID string) error {
 	return c.doRequest(ctx, "DELETE", "/threads/"+threadID, nil, nil)
 }
+
+// ForkOptions configures ForkThread.
+type ForkOptions struct {
+	// Exclusive stops before untilMessageID instead of including it
+	// (useful for "edit and regenerate from here").
+	Exclusive bool
+	// BatchSize is the number of messages per bulk request (default 100).
+	BatchSize int
+	// Metadata is merged over the copied thread metadata.
+	Metadata map[string]string
+}
+
+// ForkThread copies a thread's messages, in order, up to and including
+// untilMessageID into a new thread. An empty untilMessageID copies the whole
+// thread.
+//
+// The new thread keeps the original metadata plus "parent_thread_id" and,
+// when forking at a message, "forked_at_message_id".
+//
+// Example (A/B test two assistants on the same history):
+//
+//	fork, err := client.ForkThread(ctx, threadID, "", ForkOptions{})
+//	runA, err := client.CreateRunAndWait(ctx, threadID, assistantA, true)
+//	runB, err := client.CreateRunAndWait(ctx, fork.ID, assistantB, true)
+func (c *Client) ForkThread(ctx context.Context, threadID, untilMessageID string, opts ForkOptions) (*Thread, error) {
+	parent, err := c.GetThread(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := c.messagesUntil(ctx, threadID, untilMessageID, opts.Exclusive)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := make(map[string]string, len(parent.Metadata)+len(opts.Metadata)+2)
+	for k, v := range parent.Metadata {
+		metadata[k] = v
+	}
+	metadata["parent_thread_id"] = threadID
+	if untilMessageID != "" {
+		metadata["forked_at_message_id"] = untilMessageID
+	}
+	for k, v := range opts.Metadata {
+		metadata[k] = v
+	}
+
+	thread, err := c.CreateThreadWithMetadata(ctx, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := CopyMessages(ctx, c, thread.ID, messages, opts.BatchSize); err != nil {
+		return thread, fmt.Errorf("fork thread %s: %w", threadID, err)
+	}
+
+	return thread, nil
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// newForkServer serves a 5-message thread with pages of 2 messages and
+// records threads and bulk batches created by the client.
+func newForkServer(t *testing.T) (*Client, *map[string]string, *[][]BulkMessage) {
+	var created map[string]string
+	var batches [][]BulkMessage
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/threads/thread_1":
+			io.WriteString(w, `{"id":"thread_1","metadata":{"user":"42"}}`)
+		case r.Method == "GET" && r.URL.Path == "/threads/thread_1/messages":
+			if r.URL.Query().Get("order") != "asc" {
+				t.Errorf("Expected order=asc, got %s", r.URL.RawQuery)
+			}
+			start := 0
+			if after := r.URL.Query().Get("after"); after != "" {
+				n, _ := strconv.Atoi(strings.TrimPrefix(after, "msg_"))
+				start = n
+			}
+			var data []ThreadMessage
+			for i := start + 1; i <= 5 && len(data) < 2; i++ {
+				role := "user"
+				if i%2 == 0 {
+					role = "assistant"
+				}
+				data = append(data, ThreadMessage{ID: fmt.Sprintf("msg_%d", i), Role: role,
+					Content: []MessageContent{{Type: "text", Text: MessageContentText{Value: fmt.Sprintf("m%d", i)}}}})
+			}
+			json.NewEncoder(w).Encode(MessageList{Data: data, HasMore: start+len(data) < 5})
+		case r.Method == "POST" && r.URL.Path == "/threads":
+			var body struct {
+				Metadata map[string]string `json:"metadata"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			created = body.Metadata
+			io.WriteString(w, `{"id":"thread_2"}`)
+		case r.Method == "POST" && r.URL.Path == "/threads/thread_2/messages/bulk":
+			var body struct {
+				Messages []BulkMessage `json:"messages"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			batches = append(batches, body.Messages)
+			io.WriteString(w, `{"data":[]}`)
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return New("test-key", srv.URL, WithRetryMax(0)), &created, &batches
+}
+
+func TestForkThreadAtMessage(t *testing.T) {
+	c, created, batches := newForkServer(t)
+
+	fork, err := c.ForkThread(context.Background(), "thread_1", "msg_4", ForkOptions{BatchSize: 3})
+	if err != nil {
+		t.Fatalf("ForkThread failed: %v", err)
+	}
+	if fork.ID != "thread_2" {
+		t.Errorf("Unexpected fork: %+v", fork)
+	}
+	if (*created)["parent_thread_id"] != "thread_1" || (*created)["forked_at_message_id"] != "msg_4" || (*created)["user"] != "42" {
+		t.Errorf("Unexpected metadata: %v", *created)
+	}
+
+	var got []string
+	for _, batch := range *batches {
+		for _, m := range batch {
+			got = append(got, m.Role+":"+m.Content)
+		}
+	}
+	if want := "user:m1,assistant:m2,user:m3,assistant:m4"; strings.Join(got, ",") != want {
+		t.Errorf("Unexpected messages %v, want %s", got, want)
+	}
+	if len(*batches) != 2 || len((*batches)[0]) != 3 {
+		t.Errorf("Expected batches of 3+1, got %d batches", len(*batches))
+	}
+}
+
+func TestForkThreadExclusiveAndMissing(t *testing.T) {
+	c, _, batches := newForkServer(t)
+	ctx := context.Background()
+
+	if _, err := c.ForkThread(ctx, "thread_1", "msg_3", ForkOptions{Exclusive: true}); err != nil {
+		t.Fatalf("ForkThread failed: %v", err)
+	}
+	if n := len((*batches)[0]); n != 2 {
+		t.Errorf("Expected 2 messages before msg_3, got %d", n)
+	}
+
+	if _, err := c.ForkThread(ctx, "thread_1", "msg_99", ForkOptions{}); err == nil {
+		t.Error("Expected error for unknown message")
+	}
+}
@@ -0,0 +1,411 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+// Built-in Pixi tool names.
+const (
+	ToolWebSearch    = "WebSearch"
+	ToolFetch        = "Fetch"
+	ToolDrawImage    = "DrawImage"
+	ToolEditImage    = "EditImage"
+	ToolAnalyzeImage = "AnalyzeImage"
+	ToolExecuteCode  = "ExecuteCode"
+)
+
+// KnownPixiTools lists the built-in tools this client knows about, in the
+// order used by DefaultToolsConfig. The server may offer more; see
+// ToolsConfig.UnknownTools and ValidateStrict.
+var KnownPixiTools = []string{
+	ToolWebSearch,
+	ToolFetch,
+	ToolDrawImage,
+	ToolEditImage,
+	ToolAnalyzeImage,
+	ToolExecuteCode,
+}
+
+// ToolsConfig is an assistant's tool configuration.
+//
+// A nil *ToolsConfig on an assistant means the server default (all built-in
+// Pixi tools). The API stores tools_config as a JSON-encoded string:
+// UnmarshalJSON accepts that string or a plain object, MarshalJSON emits an
+// object, and assistant create/update requests send the Encode form.
+// Fields and tools this client does not know are preserved so configs
+// round-trip losslessly.
+type ToolsConfig struct {
+	PixiTools []PixiTool           `json:"pixi_tools,omitempty"`
+	Functions []FunctionDefinition `json:"functions,omitempty"`
+
+	extra map[string]json.RawMessage // Unknown top-level fields
+	raw   json.RawMessage            // Non-object configs kept verbatim
+}
+
+// PixiTool enables a built-in Pixi tool with optional tool-specific options.
+type PixiTool struct {
+	Name    string                 `json:"name"`
+	Options map[string]interface{} `json:"options,omitempty"`
+
+	extra map[string]json.RawMessage
+}
+
+// FunctionDefinition is a custom function tool the assistant may call.
+// Calls are executed client-side (see RunDriver).
+type FunctionDefinition struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Parameters  map[string]interface{} `json:"parameters,omitempty"` // JSON Schema object
+
+	extra map[string]json.RawMessage
+}
+
+// DefaultToolsConfig returns a config enabling every known Pixi tool, which
+// matches the server behaviour for assistants without a tools_config.
+func DefaultToolsConfig() *ToolsConfig {
+	tc := &ToolsConfig{}
+	for _, name := range KnownPixiTools {
+		tc.PixiTools = append(tc.PixiTools, PixiTool{Name: name})
+	}
+	return tc
+}
+
+// ParseToolsConfig decodes a tools_config JSON document.
+func ParseToolsConfig(data string) (*ToolsConfig, error) {
+	var tc ToolsConfig
+	if err := json.Unmarshal([]byte(data), &tc); err != nil {
+		return nil, err
+	}
+	return &tc, nil
+}
+
+// Encode returns the config as the JSON string sent in tools_config.
+func (tc *ToolsConfig) Encode() (string, error) {
+	b, err := json.Marshal(tc)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Tool returns the named Pixi tool, or nil if it is not enabled.
+func (tc *ToolsConfig) Tool(name string) *PixiTool {
+	for i := range tc.PixiTools {
+		if tc.PixiTools[i].Name == name {
+			return &tc.PixiTools[i]
+		}
+	}
+	return nil
+}
+
+// HasTool reports whether the named Pixi tool or function is enabled.
+func (tc *ToolsConfig) HasTool(name string) bool {
+	return tc.Tool(name) != nil || tc.Function(name) != nil
+}
+
+// Function returns the named function definition, or nil.
+func (tc *ToolsConfig) Function(name string) *FunctionDefinition {
+	for i := range tc.Functions {
+		if tc.Functions[i].Name == name {
+			return &tc.Functions[i]
+		}
+	}
+	return nil
+}
+
+// EnableTool enables a Pixi tool, replacing its options if already enabled.
+func (tc *ToolsConfig) EnableTool(name string, options map[string]interface{}) {
+	if t := tc.Tool(name); t != nil {
+		t.Options = options
+		return
+	}
+	tc.PixiTools = append(tc.PixiTools, PixiTool{Name: name, Options: options})
+}
+
+// DisableTool removes a Pixi tool or function by name.
+// Returns false if nothing was enabled under that name.
+func (tc *ToolsConfig) DisableTool(name string) bool {
+	removed := false
+	tools := tc.PixiTools[:0]
+	for _, t := range tc.PixiTools {
+		if t.Name == name {
+			removed = true
+			continue
+		}
+		tools = append(tools, t)
+	}
+	tc.PixiTools = tools
+
+	functions := tc.Functions[:0]
+	for _, f := range tc.Functions {
+		if f.Name == name {
+			removed = true
+			continue
+		}
+		functions = append(functions, f)
+	}
+	tc.Functions = functions
+	return removed
+}
+
+// AddFunction adds or replaces a custom function tool.
+func (tc *ToolsConfig) AddFunction(fn FunctionDefinition) {
+	if f := tc.Function(fn.Name); f != nil {
+		*f = fn
+		return
+	}
+	tc.Functions = append(tc.Functions, fn)
+}
+
+var functionNameRe = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// Validate checks the config locally so mistakes surface before the server
+// rejects them. All problems are reported together. Pixi tools missing from
+// KnownPixiTools are allowed, since the server may add tools before this
+// client knows them; use UnknownTools or ValidateStrict to catch typos.
+func (tc *ToolsConfig) Validate() error {
+	return tc.validate(false)
+}
+
+// ValidateStrict is Validate that also rejects Pixi tools missing from
+// KnownPixiTools.
+func (tc *ToolsConfig) ValidateStrict() error {
+	return tc.validate(true)
+}
+
+// UnknownTools returns the enabled Pixi tools missing from KnownPixiTools.
+func (tc *ToolsConfig) UnknownTools() []string {
+	var unknown []string
+	for _, t := range tc.PixiTools {
+		if t.Name != "" && !slices.Contains(KnownPixiTools, t.Name) {
+			unknown = append(unknown, t.Name)
+		}
+	}
+	return unknown
+}
+
+func (tc *ToolsConfig) validate(strict bool) error {
+	if tc.raw != nil {
+		return fmt.Errorf("tools_config: unsupported format %s", tc.raw)
+	}
+
+	var errs []error
+	seen := make(map[string]bool)
+	for i, t := range tc.PixiTools {
+		switch {
+		case t.Name == "":
+			errs = append(errs, fmt.Errorf("tools_config: pixi_tools[%d] has no name", i))
+		case strict && !slices.Contains(KnownPixiTools, t.Name):
+			errs = append(errs, fmt.Errorf("tools_config: unknown Pixi tool %q", t.Name))
+		case seen[t.Name]:
+			errs = append(errs, fmt.Errorf("tools_config: duplicate tool %q", t.Name))
+		}
+		seen[t.Name] = true
+	}
+
+	for i, f := range tc.Functions {
+		switch {
+		case !functionNameRe.MatchString(f.Name):
+			errs = append(errs, fmt.Errorf("tools_config: functions[%d] has invalid name %q (want 1-64 of a-z, A-Z, 0-9, _ or -)", i, f.Name))
+		case seen[f.Name]:
+			errs = append(errs, fmt.Errorf("tools_config: duplicate tool %q", f.Name))
+		}
+		seen[f.Name] = true
+
+		if f.Parameters != nil {
+			if typ, _ := f.Parameters["type"].(string); typ != "object" {
+				errs = append(errs, fmt.Errorf("tools_config: function %q parameters must be a JSON Schema with type \"object\"", f.Name))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// MarshalJSON implements json.Marshaler, re-emitting unknown fields.
+func (tc ToolsConfig) MarshalJSON() ([]byte, error) {
+	if tc.raw != nil {
+		return tc.raw, nil
+	}
+	fields := cloneExtra(tc.extra)
+	if len(tc.PixiTools) > 0 {
+		if err := setField(fields, "pixi_tools", tc.PixiTools); err != nil {
+			return nil, err
+		}
+	}
+	if len(tc.Functions) > 0 {
+		if err := setField(fields, "functions", tc.Functions); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts the config either as
+// an object or as the JSON-encoded string returned by the server.
+func (tc *ToolsConfig) UnmarshalJSON(data []byte) error {
+	*tc = ToolsConfig{}
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		data = bytes.TrimSpace([]byte(s))
+		if len(data) == 0 {
+			return nil
+		}
+	}
+	if len(data) == 0 || data[0] != '{' {
+		tc.raw = append(json.RawMessage(nil), data...)
+		return nil
+	}
+
+	fields, err := splitFields(data)
+	if err != nil {
+		return err
+	}
+	if err := takeField(fields, "pixi_tools", &tc.PixiTools); err != nil {
+		return err
+	}
+	if err := takeField(fields, "functions", &tc.Functions); err != nil {
+		return err
+	}
+	tc.extra = fields
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, re-emitting unknown fields.
+func (t PixiTool) MarshalJSON() ([]byte, error) {
+	fields := cloneExtra(t.extra)
+	if err := setField(fields, "name", t.Name); err != nil {
+		return nil, err
+	}
+	if t.Options != nil {
+		if err := setField(fields, "options", t.Options); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown fields.
+func (t *PixiTool) UnmarshalJSON(data []byte) error {
+	*t = PixiTool{}
+	fields, err := splitFields(data)
+	if err != nil {
+		return err
+	}
+	if err := takeField(fields, "name", &t.Name); err != nil {
+		return err
+	}
+	if err := takeField(fields, "options", &t.Options); err != nil {
+		return err
+	}
+	t.extra = fields
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, re-emitting unknown fields.
+func (f FunctionDefinition) MarshalJSON() ([]byte, error) {
+	fields := cloneExtra(f.extra)
+	if err := setField(fields, "name", f.Name); err != nil {
+		return nil, err
+	}
+	if f.Description != "" {
+		if err := setField(fields, "description", f.Description); err != nil {
+			return nil, err
+		}
+	}
+	if f.Parameters != nil {
+		if err := setField(fields, "parameters", f.Parameters); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown fields.
+func (f *FunctionDefinition) UnmarshalJSON(data []byte) error {
+	*f = FunctionDefinition{}
+	fields, err := splitFields(data)
+	if err != nil {
+		return err
+	}
+	if err := takeField(fields, "name", &f.Name); err != nil {
+		return err
+	}
+	if err := takeField(fields, "description", &f.Description); err != nil {
+		return err
+	}
+	if err := takeField(fields, "parameters", &f.Parameters); err != nil {
+		return err
+	}
+	f.extra = fields
+	return nil
+}
+
+// Tool returns the definition in the OpenAI tool format used by
+// ChatCompletionRequest.Tools and RunRequest.Tools.
+func (f FunctionDefinition) Tool() Tool {
+	fn := map[string]interface{}{"name": f.Name}
+	if f.Description != "" {
+		fn["description"] = f.Description
+	}
+	if f.Parameters != nil {
+		fn["parameters"] = f.Parameters
+	}
+	return Tool{"type": "function", "function": fn}
+}
+
+// splitFields decodes a JSON object into raw fields.
+func splitFields(data []byte) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		fields = make(map[string]json.RawMessage)
+	}
+	return fields, nil
+}
+
+// takeField decodes and removes a known field. Numbers inside maps decode as
+// json.Number so they re-encode exactly.
+func takeField(fields map[string]json.RawMessage, key string, dst interface{}) error {
+	raw, ok := fields[key]
+	if !ok {
+		return nil
+	}
+	delete(fields, key)
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(dst); err != nil {
+		return fmt.Errorf("tools_config: invalid %s: %w", key, err)
+	}
+	return nil
+}
+
+func setField(fields map[string]json.RawMessage, key string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	fields[key] = b
+	return nil
+}
+
+func cloneExtra(extra map[string]json.RawMessage) map[string]json.RawMessage {
+	fields := make(map[string]json.RawMessage, len(extra)+2)
+	for k, v := range extra {
+		fields[k] = v
+	}
+	return fields
+}
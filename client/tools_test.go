@@ -0,0 +1,107 @@
+package client
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestToolsConfigRoundTrip(t *testing.T) {
+	// Server returns tools_config as a JSON-encoded string, with fields this
+	// client does not know about
+	wire := `{"id":"asst_1","tools_config":"{\"pixi_tools\":[{\"name\":\"WebSearch\",\"options\":{\"max_results\":12345678901234567},\"beta\":true}],\"functions\":[{\"name\":\"get_weather\",\"parameters\":{\"type\":\"object\"},\"strict\":true}],\"version\":2}"}`
+
+	var a Assistant
+	if err := json.Unmarshal([]byte(wire), &a); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if a.ToolsConfig == nil || !a.ToolsConfig.HasTool(ToolWebSearch) || !a.ToolsConfig.HasTool("get_weather") {
+		t.Fatalf("Unexpected tools config: %+v", a.ToolsConfig)
+	}
+
+	encoded, err := a.ToolsConfig.Encode()
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	for _, want := range []string{`"beta":true`, `"strict":true`, `"version":2`, `12345678901234567`} {
+		if !strings.Contains(encoded, want) {
+			t.Errorf("Encoded config lost %s: %s", want, encoded)
+		}
+	}
+
+	again, err := ParseToolsConfig(encoded)
+	if err != nil {
+		t.Fatalf("ParseToolsConfig failed: %v", err)
+	}
+	reencoded, _ := again.Encode()
+	if reencoded != encoded {
+		t.Errorf("Round trip mismatch:\n%s\n%s", encoded, reencoded)
+	}
+}
+
+func TestToolsConfigValidate(t *testing.T) {
+	tc := DefaultToolsConfig()
+	if err := tc.Validate(); err != nil {
+		t.Fatalf("Default config invalid: %v", err)
+	}
+
+	tc.EnableTool("WebSerch", nil)
+	tc.AddFunction(FunctionDefinition{Name: "bad name"})
+	tc.AddFunction(FunctionDefinition{Name: "lookup", Parameters: map[string]interface{}{"type": "string"}})
+	tc.AddFunction(FunctionDefinition{Name: ToolFetch})
+
+	err := tc.Validate()
+	if err == nil {
+		t.Fatal("Expected validation errors, got nil")
+	}
+	for _, want := range []string{`invalid name "bad name"`, `function "lookup" parameters`, `duplicate tool "Fetch"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Missing error %q in: %v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "WebSerch") {
+		t.Errorf("Validate should allow unknown Pixi tools: %v", err)
+	}
+	if err := tc.ValidateStrict(); err == nil || !strings.Contains(err.Error(), `unknown Pixi tool "WebSerch"`) {
+		t.Errorf("ValidateStrict should reject unknown Pixi tools, got %v", err)
+	}
+	if got := tc.UnknownTools(); len(got) != 1 || got[0] != "WebSerch" {
+		t.Errorf("Expected [WebSerch], got %v", got)
+	}
+}
+
+func TestToolsConfigNewServerToolRoundTrips(t *testing.T) {
+	tc, err := ParseToolsConfig(`{"pixi_tools":[{"name":"WebSearch"},{"name":"BrandNewTool","options":{"x":1}}]}`)
+	if err != nil {
+		t.Fatalf("ParseToolsConfig failed: %v", err)
+	}
+	tc.DisableTool(ToolWebSearch)
+	reqBody := map[string]interface{}{}
+	if err := setToolsConfig(reqBody, tc); err != nil {
+		t.Fatalf("Editing a config with a newer server tool failed: %v", err)
+	}
+	if encoded, _ := reqBody["tools_config"].(string); !strings.Contains(encoded, "BrandNewTool") {
+		t.Errorf("Unknown tool dropped: %v", reqBody["tools_config"])
+	}
+}
+
+func TestToolsConfigEnableDisable(t *testing.T) {
+	tc := DefaultToolsConfig()
+	if !tc.DisableTool(ToolDrawImage) {
+		t.Fatal("DisableTool returned false for enabled tool")
+	}
+	if tc.HasTool(ToolDrawImage) {
+		t.Error("DrawImage still enabled")
+	}
+	if tc.DisableTool(ToolDrawImage) {
+		t.Error("DisableTool returned true for disabled tool")
+	}
+
+	tc.EnableTool(ToolWebSearch, map[string]interface{}{"max_results": 3})
+	if got := tc.Tool(ToolWebSearch).Options["max_results"]; got != 3 {
+		t.Errorf("Expected options to be replaced, got %v", got)
+	}
+	if n := len(tc.PixiTools); n != len(KnownPixiTools)-1 {
+		t.Errorf("Expected %d tools, got %d", len(KnownPixiTools)-1, n)
+	}
+}
@@ -1,11 +1,15 @@
 package client
 
+import "encoding/json"
+
 // Message represents a chat message.
 type Message struct {
 	Role       string     `json:"role"`
 	Content    string     `json:"content"`
 	ToolCalls  []ToolCall `json:"tool_calls,omitempty"`
 	ToolCallID *string    `json:"tool_call_id,omitempty"` // For role="tool" messages
+	// ReasoningContent carries chain of thought when replaying assistant turns
+	ReasoningContent string `json:"reasoning_content,omitempty"`
 }
 
 // ToolCall represents a function call made by the assistant.
@@ -28,13 +32,30 @@ type Tool map[string]interface{}
 // AssistantID is optional - if omitted, messages[0] must be a system message.
 // Tools can be provided to override assistant's configured tools.
 type ChatCompletionRequest struct {
-	AssistantID    string    `json:"assistant_id,omitempty"`
-	Messages       []Message `json:"messages"`
-	Temperature    float32   `json:"temperature,omitempty"`
-	MaxTokens      int       `json:"max_tokens,omitempty"`
-	EnableThinking *bool     `json:"enable_thinking,omitempty"`
-	Tools          []Tool    `json:"tools,omitempty"`
-}
+	AssistantID     string          `json:"assistant_id,omitempty"`
+	Messages        []Message       `json:"messages"`
+	Temperature     float32         `json:"temperature,omitempty"`
+	MaxTokens       int             `json:"max_tokens,omitempty"`
+	EnableThinking  *bool           `json:"enable_thinking,omitempty"`
+	ReasoningEffort ReasoningEffort `json:"reasoning_effort,omitempty"`
+	ThinkingBudget  int             `json:"thinking_budget,omitempty"` // Max reasoning tokens, 0 = server default
+	Tools           []Tool          `json:"tools,omitempty"`
+
+	// KeepReasoning sends Messages[i].ReasoningContent (and inline <think>
+	// blocks) back to the model. By default reasoning from earlier turns is
+	// stripped before sending.
+	KeepReasoning bool `json:"-"`
+}
+
+// ReasoningEffort controls how much the model thinks before answering.
+type ReasoningEffort string
+
+// Reasoning effort levels.
+const (
+	ReasoningLow    ReasoningEffort = "low"
+	ReasoningMedium ReasoningEffort = "medium"
+	ReasoningHigh   ReasoningEffort = "high"
+)
 
 // ChatCompletionChoice represents a single choice in the response.
 type ChatCompletionChoice struct {
@@ -68,8 +89,38 @@ type Thread struct {
 
 // MessageContentText represents text content in a message.
 type MessageContentText struct {
-	Value       string        `json:"value"`
-	Annotations []interface{} `json:"annotations"`
+	Value       string       `json:"value"`
+	Annotations []Annotation `json:"annotations"`
+}
+
+// Annotation marks a span of message text (Value[StartIndex:EndIndex]) as a
+// citation. Type is "file_citation", "url_citation" or "file_path"; the
+// matching field is set.
+type Annotation struct {
+	Type         string              `json:"type"`
+	Text         string              `json:"text,omitempty"` // The annotated span
+	StartIndex   int                 `json:"start_index"`
+	EndIndex     int                 `json:"end_index"`
+	FileCitation *FileCitation       `json:"file_citation,omitempty"`
+	URLCitation  *URLCitation        `json:"url_citation,omitempty"`
+	FilePath     *AnnotationFilePath `json:"file_path,omitempty"`
+}
+
+// FileCitation cites a quote from an uploaded file.
+type FileCitation struct {
+	FileID string `json:"file_id"`
+	Quote  string `json:"quote,omitempty"`
+}
+
+// URLCitation cites a web page.
+type URLCitation struct {
+	URL   string `json:"url"`
+	Title string `json:"title,omitempty"`
+}
+
+// AnnotationFilePath points to a file generated by a tool.
+type AnnotationFilePath struct {
+	FileID string `json:"file_id"`
 }
 
 // MessageContent represents content in a thread message.
@@ -87,6 +138,8 @@ type ThreadMessage struct {
 	Role             string           `json:"role"`
 	Content          []MessageContent `json:"content"`
 	ReasoningContent string           `json:"reasoning_content,omitempty"` // Chain of thought reasoning
+	ToolCalls        []ToolCall       `json:"tool_calls,omitempty"`        // Function calls made by the assistant
+	ToolCallID       *string          `json:"tool_call_id,omitempty"`      // For role="tool" messages
 	// Attachments from tool execution (Pixi tools only)
 	Sources []MessageSource `json:"sources,omitempty"`
 	Media   []MessageMedia  `json:"media,omitempty"`
@@ -129,19 +182,283 @@ type Run struct {
 	CreatedAt   int64          `json:"created_at"`
 	ThreadID    string         `json:"thread_id"`
 	AssistantID string         `json:"assistant_id"`
-	Status      string         `json:"status"` // queued, in_progress, completed, failed
+	Status      string         `json:"status"` // queued, in_progress, requires_action, completed, failed, cancelled
 	Model       string         `json:"model"`
 	Message     *ThreadMessage `json:"message,omitempty"` // Populated when completed
+	// Set when Status is "requires_action" - client-side tools must be run
+	RequiredAction *RequiredAction `json:"required_action,omitempty"`
 }
 
+// RequiredAction describes what the client must do before a run can continue.
+type RequiredAction struct {
+	Type              string                  `json:"type"` // submit_tool_outputs
+	SubmitToolOutputs SubmitToolOutputsAction `json:"submit_tool_outputs"`
+}
+
+// SubmitToolOutputsAction lists the function calls awaiting outputs.
+type SubmitToolOutputsAction struct {
+	ToolCalls []ToolCall `json:"tool_calls"`
+}
+
+// ToolOutput is the result of a client-side tool call, sent with SubmitToolOutputs.
+type ToolOutput struct {
+	ToolCallID string `json:"tool_call_id"`
+	Output     string `json:"output"`
+}
+
+// RunRequest represents a request to create a run on a thread.
+// Temperature and MaxTokens are optional - if 0, server uses defaults.
+type RunRequest struct {
+	AssistantID     string          `json:"assistant_id"`
+	Temperature     float32         `json:"temperature,omitempty"`
+	MaxTokens       int             `json:"max_tokens,omitempty"`
+	EnableThinking  bool            `json:"enable_thinking"`
+	ReasoningEffort ReasoningEffort `json:"reasoning_effort,omitempty"`
+	ThinkingBudget  int             `json:"thinking_budget,omitempty"` // Max reasoning tokens, 0 = server default
+	Tools           []Tool          `json:"tools,omitempty"`           // Client-side function tools (see RunDriver)
+	Stream          bool            `json:"stream,omitempty"`          // Set by CreateRunStream
+}
+
+// RunStep represents one step inside a run: creating a message or
+// executing tool calls.
+type RunStep struct {
+	ID          string         `json:"id"`
+	Object      string         `json:"object"`
+	CreatedAt   int64          `json:"created_at"`
+	RunID       string         `json:"run_id"`
+	ThreadID    string         `json:"thread_id"`
+	AssistantID string         `json:"assistant_id"`
+	Type        string         `json:"type"`   // message_creation, tool_calls
+	Status      string         `json:"status"` // in_progress, completed, failed, cancelled
+	StepDetails RunStepDetails `json:"step_details"`
+	LastError   *RunStepError  `json:"last_error,omitempty"`
+	CompletedAt *int64         `json:"completed_at,omitempty"`
+	FailedAt    *int64         `json:"failed_at,omitempty"`
+	CancelledAt *int64         `json:"cancelled_at,omitempty"`
+	Usage       *RunStepUsage  `json:"usage,omitempty"`
+}
+
+// RunStepDetails holds the type-specific details of a run step.
+type RunStepDetails struct {
+	Type            string                  `json:"type"` // message_creation, tool_calls
+	MessageCreation *RunStepMessageCreation `json:"message_creation,omitempty"`
+	ToolCalls       []RunStepToolCall       `json:"tool_calls,omitempty"`
+}
+
+// RunStepMessageCreation points to the message created by a step.
+type RunStepMessageCreation struct {
+	MessageID string `json:"message_id"`
+}
+
+// RunStepToolCall is a single tool call executed in a step.
+// Exactly one of Function or Pixi is set, matching Type.
+type RunStepToolCall struct {
+	ID       string           `json:"id"`
+	Type     string           `json:"type"` // function (client-side), pixi (built-in tool)
+	Function *RunStepFunction `json:"function,omitempty"`
+	Pixi     *RunStepPixiTool `json:"pixi,omitempty"`
+}
+
+// RunStepFunction is a client-side function call and its submitted output.
+type RunStepFunction struct {
+	Name      string  `json:"name"`
+	Arguments string  `json:"arguments"`        // JSON string
+	Output    *string `json:"output,omitempty"` // nil until outputs are submitted
+}
+
+// RunStepPixiTool is a built-in Pixi tool execution (WebSearch, Fetch, DrawImage, ...).
+type RunStepPixiTool struct {
+	Name       string          `json:"name"`
+	Input      json.RawMessage `json:"input,omitempty"`
+	Output     json.RawMessage `json:"output,omitempty"`
+	Error      *string         `json:"error,omitempty"`
+	DurationMs *int            `json:"duration_ms,omitempty"`
+}
+
+// RunStepError describes why a step failed.
+type RunStepError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// RunStepUsage represents token usage for a run step.
+type RunStepUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// Run streaming event types
+
+// RunEvent is an event received from a run stream. The concrete type is one
+// of *RunStatusEvent, *RunStepEvent, *MessageEvent, *MessageDeltaEvent,
+// *ToolEvent, *SourceEvent, *MediaEvent, *CodeEvent, *ErrorEvent or
+// *UnknownEvent.
+type RunEvent interface {
+	EventType() string
+}
+
+// RunStatusEvent reports a run lifecycle change
+// (thread.run.created, .queued, .in_progress, .completed, .failed, .cancelled).
+type RunStatusEvent struct {
+	Event string `json:"-"`
+	Run
+}
+
+// RunStepEvent reports run step progress (thread.run.step.created, .completed, ...).
+type RunStepEvent struct {
+	Event string `json:"-"`
+	RunStep
+}
+
+// MessageEvent carries a full message (thread.message.created, .completed).
+type MessageEvent struct {
+	Event string `json:"-"`
+	ThreadMessage
+}
+
+// MessageDeltaEvent carries incremental message output (thread.message.delta).
+type MessageDeltaEvent struct {
+	Event  string       `json:"-"`
+	ID     string       `json:"id"` // Message ID
+	Object string       `json:"object"`
+	Delta  MessageDelta `json:"delta"`
+}
+
+// MessageDelta is the incremental part of a message.
+type MessageDelta struct {
+	Content          []MessageDeltaContent `json:"content,omitempty"`
+	ReasoningContent string                `json:"reasoning_content,omitempty"` // Chain of thought fragment
+}
+
+// MessageDeltaContent is a content fragment; Index points into ThreadMessage.Content.
+type MessageDeltaContent struct {
+	Index int                 `json:"index"`
+	Type  string              `json:"type"`
+	Text  *MessageContentText `json:"text,omitempty"`
+}
+
+// ToolEvent reports Pixi tool execution (thread.tool.started, thread.tool.completed).
+type ToolEvent struct {
+	Event      string  `json:"-"`
+	ID         string  `json:"id"`
+	RunID      string  `json:"run_id"`
+	ToolName   string  `json:"tool_name"`
+	Arguments  string  `json:"arguments,omitempty"` // JSON string
+	Status     string  `json:"status"`              // in_progress, completed, failed
+	Error      *string `json:"error,omitempty"`
+	DurationMs *int    `json:"duration_ms,omitempty"` // Set on completion
+}
+
+// SourceEvent delivers a source attachment as it appears (thread.message.source).
+type SourceEvent struct {
+	Event     string `json:"-"`
+	MessageID string `json:"message_id"`
+	MessageSource
+}
+
+// MediaEvent delivers a media attachment as it appears (thread.message.media).
+type MediaEvent struct {
+	Event     string `json:"-"`
+	MessageID string `json:"message_id"`
+	MessageMedia
+}
+
+// CodeEvent delivers a code execution result as it appears (thread.message.code).
+type CodeEvent struct {
+	Event     string `json:"-"`
+	MessageID string `json:"message_id"`
+	MessageCode
+}
+
+// ErrorEvent reports a server-side error on the stream (error).
+type ErrorEvent struct {
+	Event string `json:"-"`
+	APIError
+}
+
+// UnknownEvent holds events this client version does not recognise.
+type UnknownEvent struct {
+	Event string
+	Data  []byte
+}
+
+// EventType implements RunEvent.
+func (e *RunStatusEvent) EventType() string { return e.Event }
+
+// EventType implements RunEvent.
+func (e *RunStepEvent) EventType() string { return e.Event }
+
+// EventType implements RunEvent.
+func (e *MessageEvent) EventType() string { return e.Event }
+
+// EventType implements RunEvent.
+func (e *MessageDeltaEvent) EventType() string { return e.Event }
+
+// EventType implements RunEvent.
+func (e *ToolEvent) EventType() string { return e.Event }
+
+// EventType implements RunEvent.
+func (e *SourceEvent) EventType() string { return e.Event }
+
+// EventType implements RunEvent.
+func (e *MediaEvent) EventType() string { return e.Event }
+
+// EventType implements RunEvent.
+func (e *CodeEvent) EventType() string { return e.Event }
+
+// EventType implements RunEvent.
+func (e *ErrorEvent) EventType() string { return e.Event }
+
+// EventType implements RunEvent.
+func (e *UnknownEvent) EventType() string { return e.Event }
+
 // Assistant represents an AI assistant.
 type Assistant struct {
-	ID           string  `json:"id"`
-	Object       string  `json:"object"`
-	CreatedAt    int64   `json:"created_at"`
-	Name         string  `json:"name"`
-	Instructions string  `json:"instructions"`
-	ToolsConfig  *string `json:"tools_config,omitempty"`
+	ID             string            `json:"id"`
+	Object         string            `json:"object"`
+	CreatedAt      int64             `json:"created_at"`
+	UpdatedAt      int64             `json:"updated_at,omitempty"`
+	Name           string            `json:"name"`
+	Description    string            `json:"description,omitempty"`
+	Instructions   string            `json:"instructions"`
+	Model          string            `json:"model,omitempty"`
+	Temperature    *float32          `json:"temperature,omitempty"`     // Default for runs/chat, nil = server default
+	EnableThinking *bool             `json:"enable_thinking,omitempty"` // Default thinking mode, nil = server default
+	ToolsConfig    *ToolsConfig      `json:"tools_config,omitempty"`    // nil = server default Pixi tools
+	Metadata       map[string]string `json:"metadata,omitempty"`
+}
+
+// SyncMetadataKey is the assistant metadata field assistantsync uses to
+// mark the assistants it manages. Copies of an assistant drop it.
+const SyncMetadataKey = "pixigpt_sync_key"
+
+// AssistantCreateRequest represents a request to create an assistant.
+// Optional fields left empty use server defaults.
+type AssistantCreateRequest struct {
+	Name           string            `json:"name"`
+	Instructions   string            `json:"instructions"`
+	Description    string            `json:"description,omitempty"`
+	Model          string            `json:"model,omitempty"`
+	Temperature    *float32          `json:"temperature,omitempty"`
+	EnableThinking *bool             `json:"enable_thinking,omitempty"`
+	ToolsConfig    *ToolsConfig      `json:"tools_config,omitempty"`
+	Metadata       map[string]string `json:"metadata,omitempty"`
+}
+
+// AssistantUpdateRequest represents a partial assistant update.
+// Only non-nil fields are sent; everything else is left unchanged.
+type AssistantUpdateRequest struct {
+	Name           *string           `json:"name,omitempty"`
+	Instructions   *string           `json:"instructions,omitempty"`
+	Description    *string           `json:"description,omitempty"`
+	Model          *string           `json:"model,omitempty"`
+	Temperature    *float32          `json:"temperature,omitempty"`
+	EnableThinking *bool             `json:"enable_thinking,omitempty"`
+	ToolsConfig    *ToolsConfig      `json:"tools_config,omitempty"`
+	Metadata       map[string]string `json:"metadata,omitempty"` // Replaces all metadata; empty non-nil map clears it
+	// ResetToolsConfig sends tools_config=null, restoring the default Pixi tools.
+	ResetToolsConfig bool `json:"-"`
 }
 
 // VisionUsage represents token usage for vision API calls.
@@ -218,10 +535,21 @@ type ModerationResponse struct {
 // Embeddings & Rerank API types
 
 // EmbeddingRequest represents a request to generate embeddings.
+// Use NewEmbeddingRequest or NewBatchEmbeddingRequest for type-checked input.
 type EmbeddingRequest struct {
-	Input interface{} `json:"input"` // string or []string
-	Model string      `json:"model,omitempty"` // Ignored by server
-}
+	Input          interface{} `json:"input"`                     // string or []string
+	Model          string      `json:"model,omitempty"`           // Ignored by server (single embedding model)
+	EncodingFormat string      `json:"encoding_format,omitempty"` // EncodingFloat (default) or EncodingBase64
+	Dimensions     int         `json:"dimensions,omitempty"`      // Truncate vectors (Matryoshka), 0 = full size
+	User           string      `json:"user,omitempty"`            // End-user ID for abuse monitoring
+}
+
+// Embedding encoding formats. Base64 responses are about 4x smaller and are
+// decoded into EmbeddingData.Embedding transparently.
+const (
+	EncodingFloat  = "float"
+	EncodingBase64 = "base64"
+)
 
 // EmbeddingResponse represents the response from embeddings API.
 type EmbeddingResponse struct {
@@ -0,0 +1,93 @@
+package cluster
+
+import "fmt"
+
+// Linkage defines the similarity between two clusters.
+type Linkage string
+
+// Linkage criteria.
+const (
+	Average  Linkage = "average"  // Mean pairwise similarity (default)
+	Single   Linkage = "single"   // Most similar pair; chains clusters
+	Complete Linkage = "complete" // Least similar pair; tight clusters
+)
+
+// AgglomerativeOptions configures Agglomerative. Merging stops at
+// whichever limit is reached first; at least one must be set.
+type AgglomerativeOptions struct {
+	Threshold float32 // Stop when no two clusters are at least this similar
+	K         int     // Stop at K clusters
+	Linkage   Linkage // Default Average
+}
+
+// Agglomerative clusters vectors bottom-up, repeatedly merging the two
+// most similar clusters. It is deterministic and needs no cluster count
+// when Threshold is set, but takes O(n³) time, so prefer KMeans beyond a
+// few thousand vectors.
+func Agglomerative(vectors [][]float32, opts AgglomerativeOptions) (*Clustering, error) {
+	if opts.Threshold == 0 && opts.K <= 0 {
+		return nil, fmt.Errorf("agglomerative clustering needs Threshold or K")
+	}
+	switch opts.Linkage {
+	case "":
+		opts.Linkage = Average
+	case Average, Single, Complete:
+	default:
+		return nil, fmt.Errorf("unknown linkage %q", opts.Linkage)
+	}
+	if len(vectors) == 0 {
+		return &Clustering{}, nil
+	}
+
+	normalized := normalizeAll(vectors)
+	sim := SimilarityMatrix(normalized)
+	size := make([]int, len(vectors))
+	active := make([]bool, len(vectors))
+	assignments := make([]int, len(vectors))
+	for i := range vectors {
+		size[i], active[i], assignments[i] = 1, true, i
+	}
+
+	for clusters := len(vectors); clusters > max(opts.K, 1); clusters-- {
+		a, b := -1, -1
+		var best float32
+		for i := range sim {
+			if !active[i] {
+				continue
+			}
+			for j := i + 1; j < len(sim); j++ {
+				if active[j] && (a < 0 || sim[i][j] > best) {
+					a, b, best = i, j, sim[i][j]
+				}
+			}
+		}
+		if opts.Threshold != 0 && best < opts.Threshold {
+			break
+		}
+
+		// Merge b into a, updating a's similarities (Lance-Williams)
+		for k := range sim {
+			if !active[k] || k == a || k == b {
+				continue
+			}
+			var s float32
+			switch opts.Linkage {
+			case Single:
+				s = max(sim[a][k], sim[b][k])
+			case Complete:
+				s = min(sim[a][k], sim[b][k])
+			default:
+				s = (float32(size[a])*sim[a][k] + float32(size[b])*sim[b][k]) / float32(size[a]+size[b])
+			}
+			sim[a][k], sim[k][a] = s, s
+		}
+		size[a] += size[b]
+		active[b] = false
+		for i, c := range assignments {
+			if c == b {
+				assignments[i] = a
+			}
+		}
+	}
+	return newClustering(normalized, assignments), nil
+}
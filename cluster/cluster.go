@@ -0,0 +1,176 @@
+// Package cluster groups texts by meaning using PixiGPT embeddings:
+// pairwise similarity matrices, near-duplicate detection, k-means and
+// agglomerative clustering, and cluster labels picked from the member
+// nearest each centroid or written by a chat model.
+//
+// All functions take vectors such as EmbeddingResponse.Vectors() and use
+// cosine similarity. Randomized steps take a seed, so results are
+// reproducible.
+//
+// Example:
+//
+//	resp, err := c.CreateEmbedding(ctx, client.NewBatchEmbeddingRequest(tickets))
+//	vectors := resp.Vectors()
+//
+//	keep := cluster.Dedupe(vectors, 0.95)
+//	groups, err := cluster.KMeans(vectors, cluster.KMeansOptions{K: 8, Seed: 42})
+//	labels := cluster.CentroidLabels(tickets, vectors, groups)
+package cluster
+
+import (
+	"sort"
+
+	"github.com/PixiGPT/pixigpt-go/vectorstore"
+)
+
+// Clustering is the result of KMeans or Agglomerative.
+type Clustering struct {
+	Assignments []int       // Cluster of each input vector
+	Clusters    [][]int     // Input indices per cluster, ascending
+	Centroids   [][]float32 // Normalized mean of each cluster
+}
+
+// SimilarityMatrix returns the cosine similarity of every pair of
+// vectors. The matrix is symmetric with ones on the diagonal (for
+// non-zero vectors).
+func SimilarityMatrix(vectors [][]float32) [][]float32 {
+	normalized := normalizeAll(vectors)
+	m := make([][]float32, len(vectors))
+	for i := range m {
+		m[i] = make([]float32, len(vectors))
+	}
+	for i := range normalized {
+		for j := i; j < len(normalized); j++ {
+			sim := dot(normalized[i], normalized[j])
+			m[i][j], m[j][i] = sim, sim
+		}
+	}
+	return m
+}
+
+// Pair is two vectors with their similarity; I < J.
+type Pair struct {
+	I, J       int
+	Similarity float32
+}
+
+// NearDuplicates returns every pair with similarity >= threshold, most
+// similar first.
+func NearDuplicates(vectors [][]float32, threshold float32) []Pair {
+	normalized := normalizeAll(vectors)
+	var pairs []Pair
+	for i := range normalized {
+		for j := i + 1; j < len(normalized); j++ {
+			if sim := dot(normalized[i], normalized[j]); sim >= threshold {
+				pairs = append(pairs, Pair{I: i, J: j, Similarity: sim})
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(a, b int) bool { return pairs[a].Similarity > pairs[b].Similarity })
+	return pairs
+}
+
+// DuplicateGroups returns groups of two or more vectors connected by
+// near-duplicate pairs (transitively), ordered by first member.
+func DuplicateGroups(vectors [][]float32, threshold float32) [][]int {
+	parent := make([]int, len(vectors))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for _, p := range NearDuplicates(vectors, threshold) {
+		a, b := find(p.I), find(p.J)
+		if a != b {
+			parent[max(a, b)] = min(a, b) // Root is the lowest index
+		}
+	}
+
+	members := make(map[int][]int)
+	for i := range vectors {
+		root := find(i)
+		members[root] = append(members[root], i)
+	}
+	var groups [][]int
+	for i := range vectors {
+		if g := members[i]; len(g) > 1 {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+// Dedupe returns the indices to keep so that no two kept vectors are
+// near-duplicates: the first member of each duplicate group and every
+// unique vector, in input order.
+func Dedupe(vectors [][]float32, threshold float32) []int {
+	drop := make(map[int]bool)
+	for _, g := range DuplicateGroups(vectors, threshold) {
+		for _, i := range g[1:] {
+			drop[i] = true
+		}
+	}
+	keep := make([]int, 0, len(vectors)-len(drop))
+	for i := range vectors {
+		if !drop[i] {
+			keep = append(keep, i)
+		}
+	}
+	return keep
+}
+
+// newClustering builds Clusters and Centroids from assignments, numbering
+// clusters by their first member.
+func newClustering(normalized [][]float32, assignments []int) *Clustering {
+	renumber := make(map[int]int)
+	c := &Clustering{Assignments: make([]int, len(assignments))}
+	for i, a := range assignments {
+		id, ok := renumber[a]
+		if !ok {
+			id = len(renumber)
+			renumber[a] = id
+			c.Clusters = append(c.Clusters, nil)
+		}
+		c.Assignments[i] = id
+		c.Clusters[id] = append(c.Clusters[id], i)
+	}
+	for _, members := range c.Clusters {
+		c.Centroids = append(c.Centroids, centroid(normalized, members))
+	}
+	return c
+}
+
+// centroid returns the normalized mean of the given vectors.
+func centroid(normalized [][]float32, members []int) []float32 {
+	if len(members) == 0 {
+		return nil
+	}
+	mean := make([]float32, len(normalized[members[0]]))
+	for _, i := range members {
+		for d, x := range normalized[i] {
+			mean[d] += x
+		}
+	}
+	return vectorstore.Normalize(mean)
+}
+
+func normalizeAll(vectors [][]float32) [][]float32 {
+	out := make([][]float32, len(vectors))
+	for i, v := range vectors {
+		out[i] = vectorstore.Normalize(v)
+	}
+	return out
+}
+
+func dot(a, b []float32) float32 {
+	var sum float32
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
@@ -0,0 +1,162 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/PixiGPT/pixigpt-go/client"
+)
+
+// blobs returns n noisy vectors around each of three orthogonal axes, in
+// axis order, with a fixed seed.
+func blobs(n int) [][]float32 {
+	rng := rand.New(rand.NewSource(7))
+	var out [][]float32
+	for axis := 0; axis < 3; axis++ {
+		for i := 0; i < n; i++ {
+			v := make([]float32, 3)
+			for d := range v {
+				v[d] = float32(rng.Float64() * 0.2)
+			}
+			v[axis] += 1
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// checkBlobs verifies that each blob forms exactly one cluster.
+func checkBlobs(t *testing.T, c *Clustering, n int) {
+	t.Helper()
+	if len(c.Clusters) != 3 {
+		t.Fatalf("Expected 3 clusters, got %d: %v", len(c.Clusters), c.Clusters)
+	}
+	for id, members := range c.Clusters {
+		if len(members) != n || members[0] != id*n || members[n-1] != id*n+n-1 {
+			t.Errorf("Cluster %d mixes blobs: %v", id, members)
+		}
+		if c.Centroids[id][id] < 0.9 {
+			t.Errorf("Centroid %d not on its axis: %v", id, c.Centroids[id])
+		}
+	}
+}
+
+func TestSimilarityAndDuplicates(t *testing.T) {
+	vectors := [][]float32{{1, 0}, {0.99, 0.01}, {0, 1}, {0.98, 0.02}, {-1, 0}}
+
+	m := SimilarityMatrix(vectors)
+	if math.Abs(float64(m[0][0])-1) > 1e-6 || m[0][2] != 0 || m[0][4] != -1 || m[1][3] != m[3][1] {
+		t.Errorf("Unexpected matrix %v", m)
+	}
+
+	pairs := NearDuplicates(vectors, 0.99)
+	if len(pairs) != 3 || pairs[0].I != 0 || pairs[0].J != 1 {
+		t.Errorf("Unexpected pairs %+v", pairs)
+	}
+	if got := fmt.Sprint(DuplicateGroups(vectors, 0.99)); got != "[[0 1 3]]" {
+		t.Errorf("Unexpected groups %s", got)
+	}
+	if got := fmt.Sprint(Dedupe(vectors, 0.99)); got != "[0 2 4]" {
+		t.Errorf("Unexpected kept indices %s", got)
+	}
+}
+
+func TestKMeans(t *testing.T) {
+	vectors := blobs(10)
+	c, err := KMeans(vectors, KMeansOptions{K: 3, Seed: 42})
+	if err != nil {
+		t.Fatalf("KMeans failed: %v", err)
+	}
+	checkBlobs(t, c, 10)
+
+	again, _ := KMeans(vectors, KMeansOptions{K: 3, Seed: 42})
+	if fmt.Sprint(again.Centroids) != fmt.Sprint(c.Centroids) {
+		t.Error("Same seed gave different centroids")
+	}
+
+	if _, err := KMeans(vectors, KMeansOptions{}); err == nil {
+		t.Error("Expected error for K = 0")
+	}
+	small, _ := KMeans([][]float32{{1, 0}, {1, 0}}, KMeansOptions{K: 5})
+	if len(small.Clusters) != 1 {
+		t.Errorf("Identical points should form one cluster, got %v", small.Clusters)
+	}
+}
+
+func TestAgglomerative(t *testing.T) {
+	vectors := blobs(6)
+	for _, linkage := range []Linkage{Average, Single, Complete} {
+		c, err := Agglomerative(vectors, AgglomerativeOptions{Threshold: 0.8, Linkage: linkage})
+		if err != nil {
+			t.Fatalf("%s: %v", linkage, err)
+		}
+		checkBlobs(t, c, 6)
+	}
+
+	c, err := Agglomerative(vectors, AgglomerativeOptions{K: 1})
+	if err != nil || len(c.Clusters) != 1 {
+		t.Errorf("Expected a single cluster, got %v (%v)", c, err)
+	}
+	if _, err := Agglomerative(vectors, AgglomerativeOptions{}); err == nil {
+		t.Error("Expected error without Threshold or K")
+	}
+}
+
+type fakeChat struct {
+	prompts  []string
+	requests []client.ChatCompletionRequest
+}
+
+func (f *fakeChat) CreateChatCompletion(ctx context.Context, req client.ChatCompletionRequest) (*client.ChatCompletionResponse, error) {
+	prompt := req.Messages[len(req.Messages)-1].Content
+	f.prompts = append(f.prompts, prompt)
+	f.requests = append(f.requests, req)
+	label := "Other"
+	if strings.Contains(prompt, "refund") {
+		label = "Refunds"
+	}
+	return &client.ChatCompletionResponse{Choices: []client.ChatCompletionChoice{{
+		Message: client.Message{Role: "assistant", Content: ` "` + label + `." `},
+	}}}, nil
+}
+
+func TestLabels(t *testing.T) {
+	texts := []string{"refund please", "I want my refund", "app crashes", "crash on start"}
+	vectors := [][]float32{{1, 0.1}, {1, 0}, {0, 1}, {0.1, 1}}
+	c, err := KMeans(vectors, KMeansOptions{K: 2})
+	if err != nil {
+		t.Fatalf("KMeans failed: %v", err)
+	}
+
+	centroidLabels := CentroidLabels(texts, vectors, c)
+	if len(centroidLabels) != 2 || !strings.Contains(centroidLabels[0], "refund") || !strings.Contains(centroidLabels[1], "crash") {
+		t.Errorf("Unexpected centroid labels %q", centroidLabels)
+	}
+
+	chat := &fakeChat{}
+	labels, err := ChatLabels(context.Background(), chat, texts, vectors, c, LabelOptions{Samples: 1})
+	if err != nil {
+		t.Fatalf("ChatLabels failed: %v", err)
+	}
+	if strings.Join(labels, "|") != "Refunds|Other" {
+		t.Errorf("Unexpected labels %v", labels)
+	}
+	if len(chat.prompts) != 2 || strings.Count(chat.prompts[0], "\n- ") != 1 {
+		t.Errorf("Expected one sample per prompt, got %q", chat.prompts)
+	}
+	if messages := chat.requests[0].Messages; len(messages) != 2 || messages[0].Role != "system" || !strings.Contains(messages[0].Content, "label") {
+		t.Errorf("Expected a system message without an assistant, got %+v", messages)
+	}
+
+	chat = &fakeChat{}
+	if _, err := ChatLabels(context.Background(), chat, texts, vectors, c, LabelOptions{AssistantID: "asst_1"}); err != nil {
+		t.Fatalf("ChatLabels failed: %v", err)
+	}
+	if messages := chat.requests[0].Messages; len(messages) != 1 || messages[0].Role != "user" || !strings.Contains(messages[0].Content, "label") {
+		t.Errorf("Expected one user message with an assistant, got %+v", messages)
+	}
+}
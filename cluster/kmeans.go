@@ -0,0 +1,103 @@
+package cluster
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// KMeansOptions configures KMeans.
+type KMeansOptions struct {
+	K       int   // Number of clusters (required)
+	MaxIter int   // Iteration limit (default 100)
+	Seed    int64 // Initialization seed, 0 = 1 (deterministic)
+}
+
+// KMeans partitions vectors into K clusters by cosine similarity
+// (spherical k-means with k-means++ initialization). Empty clusters are
+// dropped, so fewer than K clusters may be returned for degenerate input.
+func KMeans(vectors [][]float32, opts KMeansOptions) (*Clustering, error) {
+	if opts.K <= 0 {
+		return nil, fmt.Errorf("k-means needs K > 0, got %d", opts.K)
+	}
+	if len(vectors) == 0 {
+		return &Clustering{}, nil
+	}
+	if opts.MaxIter <= 0 {
+		opts.MaxIter = 100
+	}
+	if opts.Seed == 0 {
+		opts.Seed = 1
+	}
+	k := min(opts.K, len(vectors))
+
+	normalized := normalizeAll(vectors)
+	centroids := initCentroids(normalized, k, rand.New(rand.NewSource(opts.Seed)))
+	assignments := make([]int, len(normalized))
+
+	for iter := 0; iter < opts.MaxIter; iter++ {
+		changed := false
+		for i, v := range normalized {
+			if best := nearest(v, centroids); iter == 0 || best != assignments[i] {
+				assignments[i] = best
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+
+		members := make([][]int, k)
+		for i, a := range assignments {
+			members[a] = append(members[a], i)
+		}
+		for c := range centroids {
+			if len(members[c]) > 0 {
+				centroids[c] = centroid(normalized, members[c])
+			}
+		}
+	}
+	return newClustering(normalized, assignments), nil
+}
+
+// initCentroids picks k starting centroids with k-means++: each next
+// centroid is sampled with probability proportional to its squared
+// distance from the nearest chosen one.
+func initCentroids(normalized [][]float32, k int, rng *rand.Rand) [][]float32 {
+	centroids := [][]float32{normalized[rng.Intn(len(normalized))]}
+	dist := make([]float64, len(normalized))
+
+	for len(centroids) < k {
+		var total float64
+		for i, v := range normalized {
+			// For unit vectors, squared distance = 2 - 2*cosine
+			d := 2 - 2*float64(dot(v, centroids[nearest(v, centroids)]))
+			dist[i] = max(d, 0)
+			total += dist[i]
+		}
+		if total == 0 {
+			break // Fewer distinct points than k
+		}
+		target := rng.Float64() * total
+		pick := len(normalized) - 1
+		for i, d := range dist {
+			if target < d {
+				pick = i
+				break
+			}
+			target -= d
+		}
+		centroids = append(centroids, normalized[pick])
+	}
+	return centroids
+}
+
+// nearest returns the index of the centroid most similar to v.
+func nearest(v []float32, centroids [][]float32) int {
+	best, bestSim := 0, dot(v, centroids[0])
+	for c := 1; c < len(centroids); c++ {
+		if sim := dot(v, centroids[c]); sim > bestSim {
+			best, bestSim = c, sim
+		}
+	}
+	return best
+}
@@ -0,0 +1,88 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/PixiGPT/pixigpt-go/client"
+	"github.com/PixiGPT/pixigpt-go/internal/prompt"
+	"github.com/PixiGPT/pixigpt-go/vectorstore"
+)
+
+// Representatives returns, per cluster, up to n member indices most
+// similar to the cluster centroid, best first.
+func Representatives(vectors [][]float32, c *Clustering, n int) [][]int {
+	out := make([][]int, len(c.Clusters))
+	for id, members := range c.Clusters {
+		sims := make(map[int]float32, len(members))
+		for _, i := range members {
+			sims[i] = dot(vectorstore.Normalize(vectors[i]), c.Centroids[id])
+		}
+		ranked := append([]int(nil), members...)
+		sort.SliceStable(ranked, func(a, b int) bool { return sims[ranked[a]] > sims[ranked[b]] })
+		out[id] = ranked[:min(n, len(ranked))]
+	}
+	return out
+}
+
+// CentroidLabels labels each cluster with the text of its member nearest
+// the centroid. texts and vectors are parallel.
+func CentroidLabels(texts []string, vectors [][]float32, c *Clustering) []string {
+	labels := make([]string, len(c.Clusters))
+	for id, reps := range Representatives(vectors, c, 1) {
+		if len(reps) > 0 {
+			labels[id] = texts[reps[0]]
+		}
+	}
+	return labels
+}
+
+// ChatAPI is the subset of *client.Client used by ChatLabels.
+type ChatAPI interface {
+	CreateChatCompletion(ctx context.Context, req client.ChatCompletionRequest) (*client.ChatCompletionResponse, error)
+}
+
+// LabelOptions configures ChatLabels.
+type LabelOptions struct {
+	AssistantID string // Optional; without one the instructions go in a system message
+	Samples     int    // Representative texts sent per cluster (default 5)
+	MaxWords    int    // Requested label length (default 5)
+}
+
+// ChatLabels asks the chat model for a short label per cluster, based on
+// the members nearest its centroid. texts and vectors are parallel.
+func ChatLabels(ctx context.Context, api ChatAPI, texts []string, vectors [][]float32, c *Clustering, opts LabelOptions) ([]string, error) {
+	if opts.Samples <= 0 {
+		opts.Samples = 5
+	}
+	if opts.MaxWords <= 0 {
+		opts.MaxWords = 5
+	}
+
+	instruction := fmt.Sprintf("Write a label of at most %d words describing what these texts have in common. Reply with the label only.", opts.MaxWords)
+
+	labels := make([]string, len(c.Clusters))
+	for id, reps := range Representatives(vectors, c, opts.Samples) {
+		var b strings.Builder
+		b.WriteString("Texts:\n")
+		for _, i := range reps {
+			fmt.Fprintf(&b, "\n- %s", strings.Join(strings.Fields(texts[i]), " "))
+		}
+
+		resp, err := api.CreateChatCompletion(ctx, client.ChatCompletionRequest{
+			AssistantID: opts.AssistantID,
+			Messages:    prompt.Messages(opts.AssistantID, instruction, b.String()),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("label cluster %d: %w", id, err)
+		}
+		label, err := resp.Content()
+		if err != nil {
+			return nil, fmt.Errorf("label cluster %d: %w", id, err)
+		}
+		labels[id] = strings.Trim(strings.TrimSpace(label), `"'.`)
+	}
+	return labels, nil
+}
@@ -0,0 +1,197 @@
+// Package embedcache caches PixiGPT embeddings by content hash.
+//
+// A Cache wraps CreateEmbedding: every input is hashed, hits are served
+// from a Store, only the misses are sent to the API, and the vectors are
+// merged back in input order. Stores are pluggable: MemoryStore (LRU),
+// FileStore (one file per vector on local disk) or any implementation of
+// Store, e.g. backed by Redis or bbolt.
+//
+// Example:
+//
+//	cache := embedcache.New(c, embedcache.NewFileStore(".embeddings"), embedcache.Options{})
+//	resp, err := cache.CreateEmbedding(ctx, client.NewBatchEmbeddingRequest(texts))
+//	stats := cache.Stats() // hits, misses, cached vs billed tokens
+package embedcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/PixiGPT/pixigpt-go/client"
+)
+
+// Store holds vectors by key. Implementations must be safe for concurrent
+// use. Get omits keys it does not have.
+type Store interface {
+	Get(ctx context.Context, keys []string) (map[string][]float32, error)
+	Set(ctx context.Context, entries map[string][]float32) error
+}
+
+// Embedder is the subset of *client.Client used by the cache.
+type Embedder interface {
+	CreateEmbedding(ctx context.Context, req client.EmbeddingRequest) (*client.EmbeddingResponse, error)
+}
+
+// Options configures a Cache.
+type Options struct {
+	// Namespace is mixed into every key. Change it when the server's
+	// embedding model changes to invalidate old vectors.
+	Namespace string
+
+	// OnError is called when storing freshly embedded vectors fails. The
+	// call still returns the vectors it paid for; nil = ignore such errors.
+	OnError func(err error)
+}
+
+// Usage separates tokens served from the cache from tokens billed by the
+// API. Cached tokens are estimated with client.EstimateTokens.
+type Usage struct {
+	Hits         int64
+	Misses       int64
+	CachedTokens int64
+	BilledTokens int64
+}
+
+// Cache is a caching wrapper around CreateEmbedding.
+type Cache struct {
+	api   Embedder
+	store Store
+	opts  Options
+
+	mu    sync.Mutex
+	stats Usage
+}
+
+// New creates a cache in front of api.
+func New(api Embedder, store Store, opts Options) *Cache {
+	return &Cache{api: api, store: store, opts: opts}
+}
+
+// Key returns the cache key of text for the given output dimensions
+// (0 = full size).
+func (c *Cache) Key(text string, dimensions int) string {
+	h := sha256.New()
+	h.Write([]byte(c.opts.Namespace))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.Itoa(dimensions)))
+	h.Write([]byte{0})
+	h.Write([]byte(text))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// CreateEmbedding has the same contract as client.CreateEmbedding, so a
+// Cache can replace the client wherever an Embedder is expected.
+// Response usage reports billed tokens only; see Stats for cache hits.
+// Store read errors fail the call; write errors go to Options.OnError.
+func (c *Cache) CreateEmbedding(ctx context.Context, req client.EmbeddingRequest) (*client.EmbeddingResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var texts []string
+	switch input := req.Input.(type) {
+	case string:
+		texts = []string{input}
+	case []string:
+		texts = input
+	}
+
+	vectors, usage, err := c.embed(ctx, texts, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &client.EmbeddingResponse{
+		Object: "list",
+		Data:   make([]client.EmbeddingData, len(vectors)),
+		Usage:  client.EmbeddingUsage{PromptTokens: int(usage.BilledTokens), TotalTokens: int(usage.BilledTokens)},
+	}
+	for i, v := range vectors {
+		resp.Data[i] = client.EmbeddingData{Object: "embedding", Embedding: v, Index: i}
+	}
+	return resp, nil
+}
+
+// Embed returns one vector per text, in order, with this call's usage.
+func (c *Cache) Embed(ctx context.Context, texts []string) ([][]float32, Usage, error) {
+	if len(texts) == 0 {
+		return nil, Usage{}, nil
+	}
+	return c.embed(ctx, texts, client.NewBatchEmbeddingRequest(texts))
+}
+
+// Stats returns usage accumulated over the cache's lifetime.
+func (c *Cache) Stats() Usage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// embed serves texts from the store and embeds the unique misses with
+// the options of req.
+func (c *Cache) embed(ctx context.Context, texts []string, req client.EmbeddingRequest) ([][]float32, Usage, error) {
+	keys := make([]string, len(texts))
+	for i, text := range texts {
+		keys[i] = c.Key(text, req.Dimensions)
+	}
+
+	cached, err := c.store.Get(ctx, keys)
+	if err != nil {
+		return nil, Usage{}, fmt.Errorf("embedding cache get: %w", err)
+	}
+
+	var usage Usage
+	vectors := make([][]float32, len(texts))
+	var missTexts []string
+	missIndex := make(map[string]int) // Key -> index into missTexts
+	for i, key := range keys {
+		if v, ok := cached[key]; ok {
+			vectors[i] = v
+			usage.Hits++
+			usage.CachedTokens += int64(client.EstimateTokens(texts[i]))
+			continue
+		}
+		usage.Misses++
+		if _, dup := missIndex[key]; !dup {
+			missIndex[key] = len(missTexts)
+			missTexts = append(missTexts, texts[i])
+		}
+	}
+
+	if len(missTexts) > 0 {
+		missReq := req
+		missReq.Input = missTexts
+		resp, err := c.api.CreateEmbedding(ctx, missReq)
+		if err != nil {
+			return nil, usage, err
+		}
+		fresh := resp.Vectors()
+		if len(fresh) != len(missTexts) {
+			return nil, usage, fmt.Errorf("expected %d embeddings, got %d", len(missTexts), len(fresh))
+		}
+		usage.BilledTokens = int64(resp.Usage.TotalTokens)
+
+		entries := make(map[string][]float32, len(missTexts))
+		for i, key := range keys {
+			if vectors[i] == nil {
+				vectors[i] = fresh[missIndex[key]]
+				entries[key] = vectors[i]
+			}
+		}
+		if err := c.store.Set(ctx, entries); err != nil && c.opts.OnError != nil {
+			c.opts.OnError(fmt.Errorf("embedding cache set: %w", err))
+		}
+	}
+
+	c.mu.Lock()
+	c.stats.Hits += usage.Hits
+	c.stats.Misses += usage.Misses
+	c.stats.CachedTokens += usage.CachedTokens
+	c.stats.BilledTokens += usage.BilledTokens
+	c.mu.Unlock()
+
+	return vectors, usage, nil
+}
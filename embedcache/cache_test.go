@@ -0,0 +1,144 @@
+package embedcache
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PixiGPT/pixigpt-go/client"
+)
+
+// fakeAPI embeds text as [len(text), dimensions] and records requests.
+type fakeAPI struct {
+	requests [][]string
+}
+
+func (f *fakeAPI) CreateEmbedding(ctx context.Context, req client.EmbeddingRequest) (*client.EmbeddingResponse, error) {
+	inputs := req.Input.([]string)
+	f.requests = append(f.requests, inputs)
+	resp := &client.EmbeddingResponse{Usage: client.EmbeddingUsage{TotalTokens: 10 * len(inputs)}}
+	for i := len(inputs) - 1; i >= 0; i-- {
+		resp.Data = append(resp.Data, client.EmbeddingData{
+			Index:     i,
+			Embedding: []float32{float32(len(inputs[i])), float32(req.Dimensions)},
+		})
+	}
+	return resp, nil
+}
+
+func TestCacheServesHitsAndSendsMisses(t *testing.T) {
+	for name, store := range map[string]Store{
+		"memory": NewMemoryStore(0),
+		"file":   NewFileStore(t.TempDir()),
+	} {
+		t.Run(name, func(t *testing.T) {
+			api := &fakeAPI{}
+			cache := New(api, store, Options{})
+			ctx := context.Background()
+
+			first, usage, err := cache.Embed(ctx, []string{"a", "bb", "a"})
+			if err != nil {
+				t.Fatalf("Embed failed: %v", err)
+			}
+			if !reflect.DeepEqual(api.requests[0], []string{"a", "bb"}) {
+				t.Errorf("Duplicates should be sent once, got %v", api.requests[0])
+			}
+			if usage.Misses != 3 || usage.BilledTokens != 20 || usage.Hits != 0 {
+				t.Errorf("Unexpected usage %+v", usage)
+			}
+
+			resp, err := cache.CreateEmbedding(ctx, client.NewBatchEmbeddingRequest([]string{"bb", "ccc", "a"}))
+			if err != nil {
+				t.Fatalf("CreateEmbedding failed: %v", err)
+			}
+			if !reflect.DeepEqual(api.requests[1], []string{"ccc"}) {
+				t.Errorf("Only misses should be sent, got %v", api.requests[1])
+			}
+			want := [][]float32{{2, 0}, {3, 0}, {1, 0}}
+			if got := resp.Vectors(); !reflect.DeepEqual(got, want) {
+				t.Errorf("Expected %v, got %v", want, got)
+			}
+			if resp.Usage.TotalTokens != 10 {
+				t.Errorf("Response should report billed tokens only, got %d", resp.Usage.TotalTokens)
+			}
+			if first[2][0] != 1 {
+				t.Errorf("Unexpected first vectors %v", first)
+			}
+
+			stats := cache.Stats()
+			if stats.Hits != 2 || stats.Misses != 4 || stats.BilledTokens != 30 || stats.CachedTokens != 4 {
+				t.Errorf("Unexpected stats %+v", stats)
+			}
+		})
+	}
+}
+
+func TestCacheKeyIncludesDimensions(t *testing.T) {
+	api := &fakeAPI{}
+	cache := New(api, NewMemoryStore(0), Options{Namespace: "v1"})
+	ctx := context.Background()
+
+	req := client.NewEmbeddingRequest("text")
+	cache.CreateEmbedding(ctx, req)
+	req.Dimensions = 64
+	resp, _ := cache.CreateEmbedding(ctx, req)
+	if len(api.requests) != 2 || resp.Data[0].Embedding[1] != 64 {
+		t.Errorf("Different dimensions must not share entries: %v", api.requests)
+	}
+
+	other := New(api, NewMemoryStore(0), Options{Namespace: "v2"})
+	if cache.Key("text", 0) == other.Key("text", 0) {
+		t.Error("Namespaces must produce different keys")
+	}
+}
+
+// failingStore misses every key and fails every write.
+type failingStore struct{}
+
+func (failingStore) Get(ctx context.Context, keys []string) (map[string][]float32, error) {
+	return nil, nil
+}
+
+func (failingStore) Set(ctx context.Context, entries map[string][]float32) error {
+	return errors.New("disk full")
+}
+
+func TestCacheSetErrorKeepsVectors(t *testing.T) {
+	var reported []error
+	cache := New(&fakeAPI{}, failingStore{}, Options{OnError: func(err error) { reported = append(reported, err) }})
+
+	vectors, usage, err := cache.Embed(context.Background(), []string{"a", "bb"})
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if len(vectors) != 2 || vectors[1][0] != 2 || usage.BilledTokens != 20 {
+		t.Errorf("Unexpected vectors %v, usage %+v", vectors, usage)
+	}
+	if got := cache.Stats(); got.Misses != 2 || got.BilledTokens != 20 {
+		t.Errorf("Stats not updated: %+v", got)
+	}
+	if len(reported) != 1 || !strings.Contains(reported[0].Error(), "disk full") {
+		t.Errorf("Expected the store error to be reported, got %v", reported)
+	}
+
+	// Without OnError the error is ignored
+	if _, err := New(&fakeAPI{}, failingStore{}, Options{}).CreateEmbedding(context.Background(), client.NewEmbeddingRequest("a")); err != nil {
+		t.Errorf("CreateEmbedding failed: %v", err)
+	}
+}
+
+func TestMemoryStoreEvictsLRU(t *testing.T) {
+	store := NewMemoryStore(2)
+	ctx := context.Background()
+	store.Set(ctx, map[string][]float32{"a": {1}})
+	store.Set(ctx, map[string][]float32{"b": {2}})
+	store.Get(ctx, []string{"a"}) // a is now most recent
+	store.Set(ctx, map[string][]float32{"c": {3}})
+
+	got, _ := store.Get(ctx, []string{"a", "b", "c"})
+	if _, ok := got["b"]; ok || len(got) != 2 || store.Len() != 2 {
+		t.Errorf("Expected b evicted, got %v", got)
+	}
+}
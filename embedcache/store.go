@@ -0,0 +1,116 @@
+package embedcache
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/PixiGPT/pixigpt-go/internal/atomicfile"
+	"github.com/PixiGPT/pixigpt-go/internal/lru"
+)
+
+// MemoryStore is an in-memory LRU store.
+type MemoryStore struct {
+	lru *lru.Cache[[]float32]
+}
+
+// NewMemoryStore creates an LRU store holding up to capacity vectors
+// (0 = unbounded).
+func NewMemoryStore(capacity int) *MemoryStore {
+	return &MemoryStore{lru: lru.New[[]float32](capacity)}
+}
+
+// Get implements Store.
+func (s *MemoryStore) Get(ctx context.Context, keys []string) (map[string][]float32, error) {
+	found := make(map[string][]float32)
+	for _, key := range keys {
+		if vector, ok := s.lru.Get(key); ok {
+			found[key] = vector
+		}
+	}
+	return found, nil
+}
+
+// Set implements Store, evicting the least recently used vectors.
+func (s *MemoryStore) Set(ctx context.Context, entries map[string][]float32) error {
+	for key, vector := range entries {
+		s.lru.Set(key, vector)
+	}
+	return nil
+}
+
+// Len returns the number of cached vectors.
+func (s *MemoryStore) Len() int {
+	return s.lru.Len()
+}
+
+// FileStore keeps one little-endian float32 file per vector under a
+// directory, sharded by the first two key characters. It survives restarts
+// and can be shared by processes on the same disk.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates a store rooted at dir (created on first write).
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+func (s *FileStore) path(key string) string {
+	if len(key) < 2 {
+		return filepath.Join(s.dir, key+".f32")
+	}
+	return filepath.Join(s.dir, key[:2], key+".f32")
+}
+
+// Get implements Store.
+func (s *FileStore) Get(ctx context.Context, keys []string) (map[string][]float32, error) {
+	found := make(map[string][]float32)
+	for _, key := range keys {
+		data, err := os.ReadFile(s.path(key))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(data) == 0 || len(data)%4 != 0 {
+			continue // Corrupt entry, re-embed
+		}
+		vector := make([]float32, len(data)/4)
+		for i := range vector {
+			vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[i*4:]))
+		}
+		found[key] = vector
+	}
+	return found, nil
+}
+
+// Set implements Store. Files are written atomically.
+func (s *FileStore) Set(ctx context.Context, entries map[string][]float32) error {
+	for key, vector := range entries {
+		path := s.path(key)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+
+		data := make([]byte, 4*len(vector))
+		for i, v := range vector {
+			binary.LittleEndian.PutUint32(data[i*4:], math.Float32bits(v))
+		}
+
+		if err := atomicfile.Write(path, func(w io.Writer) error {
+			_, err := w.Write(data)
+			return err
+		}); err != nil {
+			return fmt.Errorf("write %s: %w", path, err)
+		}
+	}
+	return nil
+}
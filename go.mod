@@ -3,3 +3,5 @@ module github.com/PixiGPT/pixigpt-go
 go 1.23.4
 
 require github.com/joho/godotenv v1.5.1
+
+require gopkg.in/yaml.v3 v3.0.1
@@ -0,0 +1,276 @@
+// Package hybrid combines keyword and embedding search for PixiGPT.
+//
+// Embedding search is good at meaning but misses exact identifiers such as
+// SKU codes or error numbers. Index is a local BM25 inverted index that
+// catches those; Fuse merges its results with vectorstore results by
+// reciprocal rank fusion, and Searcher runs both with an optional Rerank
+// pass. Everything runs in-process; Index snapshots to disk like
+// vectorstore.Store.
+//
+// Example:
+//
+//	keyword := hybrid.NewIndex(hybrid.Options{})
+//	keyword.Add(hybrid.Document{ID: "sku-1", Text: "Widget SKU-48213, blue"})
+//
+//	searcher := &hybrid.Searcher{Keyword: keyword, Vectors: store, Embedder: c, Reranker: c}
+//	results, err := searcher.Search(ctx, "SKU-48213", 5, nil)
+package hybrid
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/PixiGPT/pixigpt-go/vectorstore"
+)
+
+// Tokenizer splits text into index terms.
+type Tokenizer func(text string) []string
+
+// Tokenize is the default Tokenizer. It lowercases text and emits runs of
+// letters and digits; runs joined by '-', '_', '.', '/' or ':' are also
+// emitted whole, so "SKU-48213" yields "sku", "48213" and "sku-48213".
+func Tokenize(text string) []string {
+	var tokens []string
+	var word, compound strings.Builder
+	parts := 0
+
+	flushWord := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+			parts++
+		}
+	}
+	flushCompound := func() {
+		flushWord()
+		if parts > 1 {
+			tokens = append(tokens, compound.String())
+		}
+		compound.Reset()
+		parts = 0
+	}
+
+	runes := []rune(text)
+	for i, r := range runes {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			r = unicode.ToLower(r)
+			word.WriteRune(r)
+			compound.WriteRune(r)
+		case strings.ContainsRune("-_./:", r) && word.Len() > 0 &&
+			i+1 < len(runes) && (unicode.IsLetter(runes[i+1]) || unicode.IsDigit(runes[i+1])):
+			flushWord()
+			compound.WriteRune(r)
+		default:
+			flushCompound()
+		}
+	}
+	flushCompound()
+	return tokens
+}
+
+// Options configures an Index.
+type Options struct {
+	K1        float64   // Term frequency saturation (default 1.2)
+	B         float64   // Length normalization, 0-1 (default 0.75, -1 = none)
+	Tokenizer Tokenizer `json:"-"` // Default Tokenize; not saved in snapshots
+}
+
+// Document is an indexed text with its ID and optional metadata.
+type Document struct {
+	ID       string            `json:"id"`
+	Text     string            `json:"text"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Result is a search hit. Score is the BM25 score for Index.Search, the
+// fused score for Fuse, or the rerank relevance after a Rerank pass.
+type Result struct {
+	Document
+	Score float32
+}
+
+type indexedDoc struct {
+	Document
+	length int
+	terms  map[string]int // Term frequencies
+}
+
+// Index is an in-memory BM25 inverted index. It is safe for concurrent
+// use: searches run in parallel, writes are serialized.
+type Index struct {
+	mu          sync.RWMutex
+	opts        Options
+	docs        map[string]*indexedDoc
+	postings    map[string]map[string]int // Term -> document ID -> frequency
+	totalLength int
+}
+
+// NewIndex creates an empty index.
+func NewIndex(opts Options) *Index {
+	if opts.K1 <= 0 {
+		opts.K1 = 1.2
+	}
+	if opts.B < 0 {
+		opts.B = 0
+	} else if opts.B == 0 || opts.B > 1 {
+		opts.B = 0.75
+	}
+	if opts.Tokenizer == nil {
+		opts.Tokenizer = Tokenize
+	}
+	return &Index{
+		opts:     opts,
+		docs:     make(map[string]*indexedDoc),
+		postings: make(map[string]map[string]int),
+	}
+}
+
+// Len returns the number of documents.
+func (x *Index) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return len(x.docs)
+}
+
+// Add indexes documents, replacing any with the same ID.
+func (x *Index) Add(docs ...Document) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	for _, doc := range docs {
+		if doc.ID == "" {
+			return fmt.Errorf("document ID is required")
+		}
+		x.remove(doc.ID)
+
+		d := &indexedDoc{Document: doc.clone(), terms: make(map[string]int)}
+		for _, term := range x.opts.Tokenizer(doc.Text) {
+			d.terms[term]++
+			d.length++
+		}
+		for term, tf := range d.terms {
+			if x.postings[term] == nil {
+				x.postings[term] = make(map[string]int)
+			}
+			x.postings[term][doc.ID] = tf
+		}
+		x.docs[doc.ID] = d
+		x.totalLength += d.length
+	}
+	return nil
+}
+
+// Delete removes documents by ID and returns how many existed.
+func (x *Index) Delete(ids ...string) int {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	n := 0
+	for _, id := range ids {
+		if x.remove(id) {
+			n++
+		}
+	}
+	return n
+}
+
+func (x *Index) remove(id string) bool {
+	d, ok := x.docs[id]
+	if !ok {
+		return false
+	}
+	for term := range d.terms {
+		delete(x.postings[term], id)
+		if len(x.postings[term]) == 0 {
+			delete(x.postings, term)
+		}
+	}
+	x.totalLength -= d.length
+	delete(x.docs, id)
+	return true
+}
+
+// Get returns a copy of a document by ID.
+func (x *Index) Get(id string) (Document, bool) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	d, ok := x.docs[id]
+	if !ok {
+		return Document{}, false
+	}
+	return d.Document.clone(), true
+}
+
+// Search returns up to k documents matching query, best BM25 score first.
+// Documents sharing no term with the query are not returned. Results hold
+// copies of the documents. A nil filter accepts every document.
+func (x *Index) Search(query string, k int, filter vectorstore.Filter) []Result {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	if k <= 0 || len(x.docs) == 0 {
+		return nil
+	}
+
+	n := float64(len(x.docs))
+	avgLength := float64(x.totalLength) / n
+	scores := make(map[string]float64)
+	seen := make(map[string]bool)
+
+	for _, term := range x.opts.Tokenizer(query) {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+
+		postings := x.postings[term]
+		df := float64(len(postings))
+		if df == 0 {
+			continue
+		}
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for id, tf := range postings {
+			norm := 1 - x.opts.B + x.opts.B*float64(x.docs[id].length)/avgLength
+			f := float64(tf)
+			scores[id] += idf * f * (x.opts.K1 + 1) / (f + x.opts.K1*norm)
+		}
+	}
+
+	results := make([]Result, 0, len(scores))
+	for id, score := range scores {
+		d := x.docs[id]
+		if filter != nil && !filter(d.Metadata) {
+			continue
+		}
+		results = append(results, Result{Document: d.Document.clone(), Score: float32(score)})
+	}
+	sortResults(results)
+	if len(results) > k {
+		results = results[:k]
+	}
+	return results
+}
+
+// clone copies a document's metadata, so callers cannot modify the index
+// through it.
+func (d Document) clone() Document {
+	d.Metadata = maps.Clone(d.Metadata)
+	return d
+}
+
+// sortResults orders results by score, then ID for determinism.
+func sortResults(results []Result) {
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ID < results[j].ID
+	})
+}
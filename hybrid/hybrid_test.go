@@ -0,0 +1,194 @@
+package hybrid
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PixiGPT/pixigpt-go/client"
+	"github.com/PixiGPT/pixigpt-go/rag"
+	"github.com/PixiGPT/pixigpt-go/vectorstore"
+)
+
+func ids(results []Result) string {
+	var out []string
+	for _, r := range results {
+		out = append(out, r.ID)
+	}
+	return strings.Join(out, ",")
+}
+
+var testDocs = []Document{
+	{ID: "widget", Text: "Blue widget, SKU-48213. Ships in two days.", Metadata: map[string]string{"kind": "product"}},
+	{ID: "gadget", Text: "Red gadget, SKU-99001. Ships next week.", Metadata: map[string]string{"kind": "product"}},
+	{ID: "error", Text: "Error E1042 means the payment was declined.", Metadata: map[string]string{"kind": "faq"}},
+	{ID: "shipping", Text: "Shipping usually takes two to five days for every order.", Metadata: map[string]string{"kind": "faq"}},
+}
+
+func newTestIndex(t *testing.T) *Index {
+	x := NewIndex(Options{})
+	if err := x.Add(testDocs...); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	return x
+}
+
+func TestTokenize(t *testing.T) {
+	got := strings.Join(Tokenize("Order SKU-48213 (v1.2) failed: E1042. Done."), " ")
+	want := "order sku 48213 sku-48213 v1 2 v1.2 failed e1042 done"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestIndexSearch(t *testing.T) {
+	x := newTestIndex(t)
+
+	// "sku" alone also matches gadget, but the compound term ranks widget first
+	if got := ids(x.Search("sku-48213", 3, nil)); got != "widget,gadget" {
+		t.Errorf("Expected exact SKU match first, got %s", got)
+	}
+	if got := ids(x.Search("what does E1042 mean", 3, nil)); !strings.HasPrefix(got, "error") {
+		t.Errorf("Expected error doc first, got %s", got)
+	}
+	// "days" appears in two documents; the shorter one ranks higher on tf
+	if got := ids(x.Search("ships two days", 3, nil)); got != "widget,shipping,gadget" {
+		t.Errorf("Unexpected ranking %s", got)
+	}
+	faq := vectorstore.MatchMetadata(map[string]string{"kind": "faq"})
+	if got := ids(x.Search("two days", 3, faq)); got != "shipping" {
+		t.Errorf("Filter not applied: %s", got)
+	}
+
+	if n := x.Delete("widget", "missing"); n != 1 || x.Len() != 3 {
+		t.Errorf("Delete returned %d, len %d", n, x.Len())
+	}
+	if got := ids(x.Search("sku-48213", 3, nil)); got != "gadget" {
+		t.Errorf("Deleted doc still found: %s", got)
+	}
+}
+
+func TestIndexSaveLoad(t *testing.T) {
+	x := newTestIndex(t)
+	path := filepath.Join(t.TempDir(), "keyword.gob")
+	if err := x.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := Load(path, nil)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.Len() != 4 || ids(loaded.Search("E1042", 1, nil)) != "error" {
+		t.Error("Loaded index differs")
+	}
+
+	if _, err := Decode(bytes.NewReader([]byte("junk")), nil); err == nil {
+		t.Error("Expected decode error")
+	}
+}
+
+func TestIndexOptionsAndCopies(t *testing.T) {
+	if b := NewIndex(Options{}).opts.B; b != 0.75 {
+		t.Errorf("Expected default B 0.75, got %v", b)
+	}
+	x := NewIndex(Options{B: -1})
+	if x.opts.B != 0 {
+		t.Errorf("Expected B 0 for -1, got %v", x.opts.B)
+	}
+	x.Add(testDocs...)
+	var buf bytes.Buffer
+	if err := x.Encode(&buf); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	if loaded, err := Decode(&buf, nil); err != nil || loaded.opts.B != 0 {
+		t.Errorf("B 0 lost in a round trip: %v", err)
+	}
+
+	doc, _ := x.Get("widget")
+	doc.Metadata["kind"] = "changed"
+	results := x.Search("sku-48213", 1, nil)
+	results[0].Metadata["kind"] = "changed"
+	testDocs[1].Metadata["kind"] = "changed" // Caller's map after Add
+	defer func() { testDocs[1].Metadata["kind"] = "product" }()
+	for _, id := range []string{"widget", "gadget"} {
+		if doc, _ := x.Get(id); doc.Metadata["kind"] != "product" {
+			t.Errorf("%s modified through a shared map: %v", id, doc.Metadata)
+		}
+	}
+}
+
+func TestFuse(t *testing.T) {
+	keyword := []Result{{Document: Document{ID: "a", Text: "A"}}, {Document: Document{ID: "b"}}}
+	vector := []Result{{Document: Document{ID: "b"}}, {Document: Document{ID: "c"}}, {Document: Document{ID: "a"}}}
+
+	fused := Fuse(60, keyword, vector)
+	// b: 1/62+1/61, a: 1/61+1/63, c: 1/62
+	if got := ids(fused); got != "b,a,c" {
+		t.Errorf("Expected b,a,c, got %s", got)
+	}
+	if fused[1].Text != "A" {
+		t.Error("Text from the first list not kept")
+	}
+}
+
+// fakeAPI embeds every query as [1, 0] and reranks documents mentioning
+// "declined" highest.
+type fakeAPI struct{}
+
+func (fakeAPI) CreateEmbedding(ctx context.Context, req client.EmbeddingRequest) (*client.EmbeddingResponse, error) {
+	return &client.EmbeddingResponse{Data: []client.EmbeddingData{{Embedding: []float32{1, 0}}}}, nil
+}
+
+func (fakeAPI) Rerank(ctx context.Context, req client.RerankRequest) (*client.RerankResponse, error) {
+	resp := &client.RerankResponse{}
+	for i, doc := range req.Documents {
+		score := float32(0.1)
+		if strings.Contains(doc, "declined") {
+			score = 0.9
+		}
+		resp.Results = append(resp.Results, client.RerankData{Index: i, RelevanceScore: score})
+	}
+	return resp, nil
+}
+
+func TestSearcher(t *testing.T) {
+	store := vectorstore.New(vectorstore.Options{})
+	store.Add(
+		vectorstore.Document{ID: "shipping", Vector: []float32{1, 0}, Text: testDocs[3].Text},
+		vectorstore.Document{ID: "error", Vector: []float32{0.6, 0.8}, Text: testDocs[2].Text},
+		vectorstore.Document{ID: "widget", Vector: []float32{0, 1}, Text: testDocs[0].Text},
+	)
+	searcher := &Searcher{Keyword: newTestIndex(t), Vectors: store, Embedder: fakeAPI{}}
+
+	// Vector search alone ranks shipping first; the exact keyword hit wins
+	// after fusion because it ranks high in both lists
+	results, err := searcher.Search(context.Background(), "E1042", 2, nil)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if got := ids(results); got != "error,shipping" {
+		t.Errorf("Expected error,shipping, got %s", got)
+	}
+
+	searcher.Reranker = fakeAPI{}
+	searcher.MinScore = 0.5
+	results, err = searcher.Search(context.Background(), "two days", 3, nil)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if got := ids(results); got != "error" || results[0].Score != 0.9 {
+		t.Errorf("Expected reranked error only, got %s", got)
+	}
+
+	var _ rag.Retriever = searcher
+	searcher.Reranker = nil
+	passages, err := searcher.Retrieve(context.Background(), rag.Query{Text: "E1042", Vector: []float32{0.6, 0.8}}, 1)
+	if err != nil {
+		t.Fatalf("Retrieve failed: %v", err)
+	}
+	if len(passages) != 1 || passages[0].ID != "error" || passages[0].Metadata["kind"] != "faq" {
+		t.Errorf("Unexpected passages %+v", passages)
+	}
+}
@@ -0,0 +1,71 @@
+package hybrid
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/PixiGPT/pixigpt-go/internal/atomicfile"
+)
+
+// snapshotVersion is the file format version written by Save.
+const snapshotVersion = 1
+
+// snapshot is the on-disk form of an index. Postings are rebuilt on load.
+type snapshot struct {
+	Version int
+	K1, B   float64
+	Docs    []Document
+}
+
+// Encode writes the index's parameters and documents to w (gob encoded).
+func (x *Index) Encode(w io.Writer) error {
+	x.mu.RLock()
+	snap := snapshot{Version: snapshotVersion, K1: x.opts.K1, B: x.opts.B, Docs: make([]Document, 0, len(x.docs))}
+	for _, d := range x.docs {
+		snap.Docs = append(snap.Docs, d.Document)
+	}
+	x.mu.RUnlock()
+
+	sort.Slice(snap.Docs, func(i, j int) bool { return snap.Docs[i].ID < snap.Docs[j].ID })
+	return gob.NewEncoder(w).Encode(snap)
+}
+
+// Decode reads an index written by Encode. Tokenizers are not saved, so
+// pass the one the index was built with (nil = Tokenize).
+func Decode(r io.Reader, tokenizer Tokenizer) (*Index, error) {
+	var snap snapshot
+	if err := gob.NewDecoder(r).Decode(&snap); err != nil {
+		return nil, fmt.Errorf("failed to decode keyword index: %w", err)
+	}
+	if snap.Version < 1 || snap.Version > snapshotVersion {
+		return nil, fmt.Errorf("unsupported keyword index version %d (max %d)", snap.Version, snapshotVersion)
+	}
+
+	b := snap.B
+	if b == 0 {
+		b = -1 // Saved without length normalization
+	}
+	x := NewIndex(Options{K1: snap.K1, B: b, Tokenizer: tokenizer})
+	if err := x.Add(snap.Docs...); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// Save writes the index to path atomically.
+func (x *Index) Save(path string) error {
+	return atomicfile.Write(path, x.Encode)
+}
+
+// Load reads an index saved with Save (see Decode for tokenizer).
+func Load(path string, tokenizer Tokenizer) (*Index, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Decode(f, tokenizer)
+}
@@ -0,0 +1,134 @@
+package hybrid
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PixiGPT/pixigpt-go/client"
+	"github.com/PixiGPT/pixigpt-go/rag"
+	"github.com/PixiGPT/pixigpt-go/vectorstore"
+)
+
+// DefaultRRFK is the rank constant of reciprocal rank fusion.
+const DefaultRRFK = 60
+
+// Fuse merges ranked result lists by reciprocal rank fusion: a document
+// scores the sum of 1/(k+rank) over the lists it appears in (rank from 1),
+// so only positions matter, not the incomparable BM25 and cosine scores.
+// k <= 0 uses DefaultRRFK. Text and metadata come from the first list
+// containing the document.
+func Fuse(k int, lists ...[]Result) []Result {
+	if k <= 0 {
+		k = DefaultRRFK
+	}
+
+	byID := make(map[string]int)
+	var fused []Result
+	for _, list := range lists {
+		for rank, r := range list {
+			score := float32(1 / float64(k+rank+1))
+			if i, ok := byID[r.ID]; ok {
+				fused[i].Score += score
+				continue
+			}
+			byID[r.ID] = len(fused)
+			fused = append(fused, Result{Document: r.Document, Score: score})
+		}
+	}
+	sortResults(fused)
+	return fused
+}
+
+// FromVectors converts vectorstore results for Fuse.
+func FromVectors(results []vectorstore.Result) []Result {
+	out := make([]Result, len(results))
+	for i, r := range results {
+		out[i] = Result{Document: Document{ID: r.ID, Text: r.Text, Metadata: r.Metadata}, Score: r.Score}
+	}
+	return out
+}
+
+// Searcher runs keyword and vector search over the same document IDs,
+// fuses the results and optionally reranks them.
+type Searcher struct {
+	Keyword  *Index               // Required
+	Vectors  *vectorstore.Store   // Optional; nil = keyword search only
+	Embedder vectorstore.Embedder // Embeds queries for Vectors
+	Reranker client.Reranker      // Optional Rerank pass over the fused list
+
+	Candidates int     // Results taken from each search (default 50)
+	RRFK       int     // Fusion constant (default DefaultRRFK)
+	MinScore   float32 // Minimum rerank relevance, ignored without Reranker
+}
+
+// Search embeds query (when Vectors is set) and returns up to k results.
+func (s *Searcher) Search(ctx context.Context, query string, k int, filter vectorstore.Filter) ([]Result, error) {
+	var vector []float32
+	if s.Vectors != nil {
+		if s.Embedder == nil {
+			return nil, fmt.Errorf("hybrid search needs an Embedder for vector search")
+		}
+		resp, err := s.Embedder.CreateEmbedding(ctx, client.NewEmbeddingRequest(query))
+		if err != nil {
+			return nil, fmt.Errorf("embed query: %w", err)
+		}
+		vectors := resp.Vectors()
+		if len(vectors) != 1 {
+			return nil, fmt.Errorf("expected 1 query embedding, got %d", len(vectors))
+		}
+		vector = vectors[0]
+	}
+	return s.SearchVector(ctx, query, vector, k, filter)
+}
+
+// SearchVector is Search with a precomputed query embedding (ignored
+// when Vectors is nil).
+func (s *Searcher) SearchVector(ctx context.Context, query string, vector []float32, k int, filter vectorstore.Filter) ([]Result, error) {
+	if k <= 0 {
+		return nil, nil
+	}
+	candidates := s.Candidates
+	if candidates <= 0 {
+		candidates = 50
+	}
+	candidates = max(candidates, k)
+
+	lists := [][]Result{s.Keyword.Search(query, candidates, filter)}
+	if s.Vectors != nil {
+		results, err := s.Vectors.Search(vector, candidates, filter)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, FromVectors(results))
+	}
+	fused := Fuse(s.RRFK, lists...)
+
+	if s.Reranker == nil || len(fused) == 0 {
+		return fused[:min(k, len(fused))], nil
+	}
+	ranked, err := client.RerankItems(ctx, s.Reranker, query, fused,
+		func(r Result) string { return r.Text },
+		client.RerankOptions{TopK: k, MinScore: s.MinScore})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Result, len(ranked))
+	for i, r := range ranked {
+		out[i] = Result{Document: r.Item.Document, Score: r.Score}
+	}
+	return out, nil
+}
+
+// Retrieve implements rag.Retriever, reusing the pipeline's query
+// embedding. Leave Reranker nil when the pipeline reranks itself.
+func (s *Searcher) Retrieve(ctx context.Context, query rag.Query, k int) ([]rag.Passage, error) {
+	results, err := s.SearchVector(ctx, query.Text, query.Vector, k, nil)
+	if err != nil {
+		return nil, err
+	}
+	passages := make([]rag.Passage, len(results))
+	for i, r := range results {
+		passages[i] = rag.Passage{ID: r.ID, Text: r.Text, Score: r.Score, Metadata: r.Metadata}
+	}
+	return passages, nil
+}
@@ -0,0 +1,29 @@
+// Package atomicfile writes files atomically, for the Save methods of
+// vectorstore and hybrid and the embedcache FileStore.
+package atomicfile
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Write calls write with a temporary file next to path and renames it over
+// path once write succeeds. On any error the temporary file is removed and
+// path is left untouched.
+func Write(path string, write func(w io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // No-op after a successful rename
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
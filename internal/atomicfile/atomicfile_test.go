@@ -0,0 +1,37 @@
+package atomicfile
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.bin")
+
+	if err := Write(path, func(w io.Writer) error {
+		_, err := io.WriteString(w, "v1")
+		return err
+	}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	failed := errors.New("encode failed")
+	err := Write(path, func(w io.Writer) error {
+		io.WriteString(w, "partial")
+		return failed
+	})
+	if !errors.Is(err, failed) {
+		t.Errorf("Expected the write error, got %v", err)
+	}
+
+	if data, _ := os.ReadFile(path); string(data) != "v1" {
+		t.Errorf("Failed write changed the file: %q", data)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("Failed write left files behind: %v", entries)
+	}
+}
@@ -0,0 +1,100 @@
+// Package lru is the least recently used map behind the in-memory stores
+// of embedcache and chatcache.
+package lru
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Cache maps keys to values, evicting the least recently used entries
+// beyond its capacity. It is safe for concurrent use.
+type Cache[V any] struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // Front = most recently used
+	entries  map[string]*list.Element
+	onEvict  []func(key string, value V)
+}
+
+type entry[V any] struct {
+	key   string
+	value V
+}
+
+// New creates a cache holding up to capacity entries (0 = unbounded).
+func New[V any](capacity int) *Cache[V] {
+	return &Cache[V]{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// OnEvict registers f to be called with every entry evicted for capacity,
+// after the cache is unlocked. Delete does not call it.
+func (c *Cache[V]) OnEvict(f func(key string, value V)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onEvict = append(c.onEvict, f)
+}
+
+// Get returns the value of key and marks it most recently used.
+func (c *Cache[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*entry[V]).value, true
+}
+
+// Set stores value under key, evicting the least recently used entries.
+func (c *Cache[V]) Set(key string, value V) {
+	c.mu.Lock()
+	if el, ok := c.entries[key]; ok {
+		el.Value.(*entry[V]).value = value
+		c.order.MoveToFront(el)
+	} else {
+		c.entries[key] = c.order.PushFront(&entry[V]{key: key, value: value})
+	}
+
+	var evicted []*entry[V]
+	for c.capacity > 0 && c.order.Len() > c.capacity {
+		oldest := c.order.Remove(c.order.Back()).(*entry[V])
+		delete(c.entries, oldest.key)
+		evicted = append(evicted, oldest)
+	}
+	onEvict := c.onEvict
+	c.mu.Unlock()
+
+	for _, e := range evicted {
+		for _, f := range onEvict {
+			f(e.key, e.value)
+		}
+	}
+}
+
+// Delete removes key and reports whether it existed.
+func (c *Cache[V]) Delete(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if ok {
+		c.order.Remove(el)
+		delete(c.entries, key)
+	}
+	return ok
+}
+
+// Len returns the number of entries.
+func (c *Cache[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,31 @@
+package lru
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEvictsLeastRecentlyUsed(t *testing.T) {
+	c := New[int](2)
+	var evicted []string
+	c.OnEvict(func(key string, value int) { evicted = append(evicted, fmt.Sprint(key, value)) })
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a") // a is now most recent
+	c.Set("c", 3)
+	c.Set("a", 10) // Update, no eviction
+
+	if _, ok := c.Get("b"); ok || c.Len() != 2 {
+		t.Errorf("Expected b evicted, len %d", c.Len())
+	}
+	if v, _ := c.Get("a"); v != 10 {
+		t.Errorf("Expected updated value 10, got %d", v)
+	}
+	if !c.Delete("c") || c.Delete("c") || c.Len() != 1 {
+		t.Error("Delete should remove c once")
+	}
+	if fmt.Sprint(evicted) != "[b2]" {
+		t.Errorf("Unexpected evictions %v", evicted)
+	}
+}
@@ -0,0 +1,19 @@
+// Package prompt builds the chat messages for the helper packages (rag,
+// cluster) that send their own instructions with a user turn.
+package prompt
+
+import "github.com/PixiGPT/pixigpt-go/client"
+
+// Messages returns the messages carrying instructions and content. Without
+// an assistant the instructions are the system message. An assistant has a
+// system prompt of its own that must not be replaced, so the instructions
+// lead the user turn instead.
+func Messages(assistantID, instructions, content string) []client.Message {
+	if assistantID != "" {
+		return []client.Message{{Role: "user", Content: instructions + "\n\n" + content}}
+	}
+	return []client.Message{
+		{Role: "system", Content: instructions},
+		{Role: "user", Content: content},
+	}
+}
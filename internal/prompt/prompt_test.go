@@ -0,0 +1,15 @@
+package prompt
+
+import "testing"
+
+func TestMessages(t *testing.T) {
+	msgs := Messages("", "Be brief.", "Hi")
+	if len(msgs) != 2 || msgs[0].Role != "system" || msgs[0].Content != "Be brief." || msgs[1].Content != "Hi" {
+		t.Errorf("Unexpected messages without assistant: %+v", msgs)
+	}
+
+	msgs = Messages("asst_1", "Be brief.", "Hi")
+	if len(msgs) != 1 || msgs[0].Role != "user" || msgs[0].Content != "Be brief.\n\nHi" {
+		t.Errorf("Unexpected messages with assistant: %+v", msgs)
+	}
+}
@@ -0,0 +1,245 @@
+// Package rag answers questions from your own documents with PixiGPT:
+// embed the question, retrieve candidates from any vector index, rerank
+// them with Rerank, pack the best into a token budget and ask
+// CreateChatCompletion for an answer with numbered citations.
+//
+// Example:
+//
+//	pipeline := &rag.Pipeline{
+//	    API:         c,
+//	    Retriever:   &rag.VectorStoreRetriever{Store: store},
+//	    AssistantID: assistantID,
+//	}
+//	answer, err := pipeline.Answer(ctx, "How do I rotate my API key?")
+//	fmt.Println(answer.Text)
+//	for _, p := range answer.Citations {
+//	    fmt.Printf("[%d] %s\n", p.Number, p.ID)
+//	}
+package rag
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PixiGPT/pixigpt-go/client"
+	"github.com/PixiGPT/pixigpt-go/internal/prompt"
+)
+
+// API is the subset of *client.Client used by the pipeline.
+type API interface {
+	CreateEmbedding(ctx context.Context, req client.EmbeddingRequest) (*client.EmbeddingResponse, error)
+	Rerank(ctx context.Context, req client.RerankRequest) (*client.RerankResponse, error)
+	CreateChatCompletion(ctx context.Context, req client.ChatCompletionRequest) (*client.ChatCompletionResponse, error)
+}
+
+// Query is what a Retriever searches for. Vector is the embedded Text.
+type Query struct {
+	Text   string
+	Vector []float32
+}
+
+// Passage is a retrieved piece of text.
+type Passage struct {
+	ID       string
+	Text     string
+	Score    float32 // Retriever score, replaced by the rerank relevance
+	Metadata map[string]string
+	Number   int // Citation number in the prompt, set by Answer
+}
+
+// Retriever finds the k passages most relevant to a query.
+type Retriever interface {
+	Retrieve(ctx context.Context, query Query, k int) ([]Passage, error)
+}
+
+// DefaultSystemPrompt instructs the model to answer from numbered sources.
+const DefaultSystemPrompt = `Answer the question using only the numbered sources provided.
+Cite every fact with the number of its source in square brackets, e.g. [1] or [2][3].
+If the sources do not contain the answer, say that you don't know.`
+
+// Pipeline is a retrieval-augmented generation pipeline.
+type Pipeline struct {
+	API       API
+	Retriever Retriever
+
+	// AssistantID is used for the chat call; empty = no assistant
+	// (SystemPrompt becomes the system message).
+	AssistantID  string
+	SystemPrompt string // Default DefaultSystemPrompt
+
+	TopN          int     // Candidates retrieved (default 20)
+	TopK          int     // Passages kept after rerank (default 5)
+	MinRelevance  float32 // Drop reranked passages below this score
+	ContextTokens int     // Token budget for sources (default 3000)
+	SkipRerank    bool    // Use retriever order and scores as-is
+
+	Temperature float32
+	MaxTokens   int
+}
+
+// Answer is a generated answer with the sources behind it.
+type Answer struct {
+	Text      string
+	Reasoning string
+	Passages  []Passage // Sources given to the model, numbered from 1
+	Citations []Passage // Sources the answer cites, in order of first citation
+	Response  *client.ChatCompletionResponse
+}
+
+// Answer runs the full pipeline for one question.
+func (p *Pipeline) Answer(ctx context.Context, question string) (*Answer, error) {
+	passages, err := p.Retrieve(ctx, question)
+	if err != nil {
+		return nil, err
+	}
+	passages = p.pack(passages)
+
+	req := client.ChatCompletionRequest{
+		AssistantID: p.AssistantID,
+		Messages:    p.messages(question, passages),
+		Temperature: p.Temperature,
+		MaxTokens:   p.MaxTokens,
+	}
+	resp, err := p.API.CreateChatCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	text, err := resp.Content()
+	if err != nil {
+		return nil, err
+	}
+	reasoning, _ := resp.Reasoning()
+
+	return &Answer{
+		Text:      text,
+		Reasoning: reasoning,
+		Passages:  passages,
+		Citations: Cited(text, passages),
+		Response:  resp,
+	}, nil
+}
+
+// Retrieve embeds the question, retrieves TopN candidates and reranks
+// them down to TopK, without calling chat.
+func (p *Pipeline) Retrieve(ctx context.Context, question string) ([]Passage, error) {
+	topN, topK := p.TopN, p.TopK
+	if topK <= 0 {
+		topK = 5
+	}
+	if topN <= 0 {
+		topN = max(20, topK)
+	}
+
+	resp, err := p.API.CreateEmbedding(ctx, client.NewEmbeddingRequest(question))
+	if err != nil {
+		return nil, fmt.Errorf("embed question: %w", err)
+	}
+	vectors := resp.Vectors()
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("expected 1 question embedding, got %d", len(vectors))
+	}
+
+	candidates, err := p.Retriever.Retrieve(ctx, Query{Text: question, Vector: vectors[0]}, topN)
+	if err != nil {
+		return nil, fmt.Errorf("retrieve: %w", err)
+	}
+	if len(candidates) == 0 || p.SkipRerank {
+		return candidates[:min(topK, len(candidates))], nil
+	}
+
+	docs := make([]string, len(candidates))
+	for i, c := range candidates {
+		docs[i] = c.Text
+	}
+	ranked, err := p.API.Rerank(ctx, client.RerankRequest{Query: question, Documents: docs, TopK: topK})
+	if err != nil {
+		return nil, fmt.Errorf("rerank: %w", err)
+	}
+
+	var out []Passage
+	for _, r := range ranked.Results {
+		if r.Index < 0 || r.Index >= len(candidates) || r.RelevanceScore < p.MinRelevance {
+			continue
+		}
+		passage := candidates[r.Index]
+		passage.Score = r.RelevanceScore
+		out = append(out, passage)
+		if len(out) == topK {
+			break
+		}
+	}
+	return out, nil
+}
+
+// pack keeps passages, in order, while they fit the token budget, and
+// numbers them.
+func (p *Pipeline) pack(passages []Passage) []Passage {
+	budget := p.ContextTokens
+	if budget <= 0 {
+		budget = 3000
+	}
+
+	var out []Passage
+	used := 0
+	for _, passage := range passages {
+		n := client.EstimateTokens(passage.Text)
+		if used+n > budget {
+			continue // A shorter passage further down may still fit
+		}
+		used += n
+		passage.Number = len(out) + 1
+		out = append(out, passage)
+	}
+	return out
+}
+
+// messages builds the prompt: numbered sources, then the question.
+func (p *Pipeline) messages(question string, passages []Passage) []client.Message {
+	system := p.SystemPrompt
+	if system == "" {
+		system = DefaultSystemPrompt
+	}
+
+	var b strings.Builder
+	b.WriteString("Sources:\n\n")
+	for _, passage := range passages {
+		fmt.Fprintf(&b, "[%d] %s\n\n", passage.Number, strings.TrimSpace(passage.Text))
+	}
+	if len(passages) == 0 {
+		b.WriteString("(no sources found)\n\n")
+	}
+	b.WriteString("Question: ")
+	b.WriteString(question)
+
+	return prompt.Messages(p.AssistantID, system, b.String())
+}
+
+var citationPattern = regexp.MustCompile(`\[(\d+(?:\s*,\s*\d+)*)\]`)
+
+// Cited returns the passages referenced as [n] (or [n, m]) in text, in
+// order of first citation. Unknown numbers are ignored.
+func Cited(text string, passages []Passage) []Passage {
+	byNumber := make(map[int]Passage, len(passages))
+	for _, passage := range passages {
+		byNumber[passage.Number] = passage
+	}
+
+	var out []Passage
+	seen := make(map[int]bool)
+	for _, m := range citationPattern.FindAllStringSubmatch(text, -1) {
+		for _, part := range strings.Split(m[1], ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil || seen[n] {
+				continue
+			}
+			if passage, ok := byNumber[n]; ok {
+				seen[n] = true
+				out = append(out, passage)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,134 @@
+package rag
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/PixiGPT/pixigpt-go/client"
+	"github.com/PixiGPT/pixigpt-go/vectorstore"
+)
+
+// fakeAPI embeds known texts, reranks by keyword and answers with a fixed
+// reply, recording the chat request.
+type fakeAPI struct {
+	vectors map[string][]float32
+	reply   string
+	chat    client.ChatCompletionRequest
+	rerank  client.RerankRequest
+}
+
+func (f *fakeAPI) CreateEmbedding(ctx context.Context, req client.EmbeddingRequest) (*client.EmbeddingResponse, error) {
+	text := req.Input.(string)
+	return &client.EmbeddingResponse{Data: []client.EmbeddingData{{Embedding: f.vectors[text]}}}, nil
+}
+
+func (f *fakeAPI) Rerank(ctx context.Context, req client.RerankRequest) (*client.RerankResponse, error) {
+	f.rerank = req
+	resp := &client.RerankResponse{}
+	// Documents mentioning "key" are relevant, others barely
+	for i, doc := range req.Documents {
+		if strings.Contains(doc, "key") {
+			resp.Results = append(resp.Results, client.RerankData{Index: i, RelevanceScore: 0.9})
+		}
+	}
+	for i, doc := range req.Documents {
+		if !strings.Contains(doc, "key") {
+			resp.Results = append(resp.Results, client.RerankData{Index: i, RelevanceScore: 0.05})
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeAPI) CreateChatCompletion(ctx context.Context, req client.ChatCompletionRequest) (*client.ChatCompletionResponse, error) {
+	f.chat = req
+	return &client.ChatCompletionResponse{Choices: []client.ChatCompletionChoice{{
+		Message: client.Message{Role: "assistant", Content: f.reply},
+	}}}, nil
+}
+
+func newTestStore(t *testing.T) *vectorstore.Store {
+	store := vectorstore.New(vectorstore.Options{})
+	err := store.Add(
+		vectorstore.Document{ID: "billing", Vector: []float32{0.9, 0.1, 0}, Text: "Invoices are sent monthly."},
+		vectorstore.Document{ID: "rotate", Vector: []float32{0.8, 0.3, 0}, Text: "Rotate your API key in Settings > Keys."},
+		vectorstore.Document{ID: "revoke", Vector: []float32{0.7, 0.4, 0}, Text: "Revoking a key takes effect immediately."},
+		vectorstore.Document{ID: "weather", Vector: []float32{0, 0, 1}, Text: "It rains a lot in autumn."},
+	)
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	return store
+}
+
+func TestPipelineAnswer(t *testing.T) {
+	api := &fakeAPI{
+		vectors: map[string][]float32{"How do I rotate my key?": {1, 0.2, 0}},
+		reply:   "Go to Settings > Keys [1]. Old keys stop working at once [2, 1]. See also [9].",
+	}
+	pipeline := &Pipeline{
+		API:          api,
+		Retriever:    &VectorStoreRetriever{Store: newTestStore(t)},
+		TopN:         3,
+		TopK:         2,
+		MinRelevance: 0.5,
+	}
+
+	answer, err := pipeline.Answer(context.Background(), "How do I rotate my key?")
+	if err != nil {
+		t.Fatalf("Answer failed: %v", err)
+	}
+
+	if len(api.rerank.Documents) != 3 {
+		t.Errorf("Expected 3 candidates reranked, got %d", len(api.rerank.Documents))
+	}
+	if len(answer.Passages) != 2 || answer.Passages[0].ID != "rotate" || answer.Passages[1].ID != "revoke" {
+		t.Fatalf("Unexpected passages %+v", answer.Passages)
+	}
+	if answer.Passages[0].Number != 1 || answer.Passages[0].Score != 0.9 {
+		t.Errorf("Passage not numbered/scored: %+v", answer.Passages[0])
+	}
+
+	if len(api.chat.Messages) != 2 || api.chat.Messages[0].Role != "system" {
+		t.Fatalf("Expected system + user messages, got %+v", api.chat.Messages)
+	}
+	prompt := api.chat.Messages[1].Content
+	if !strings.Contains(prompt, "[1] Rotate your API key") || !strings.Contains(prompt, "Question: How do I rotate my key?") {
+		t.Errorf("Unexpected prompt:\n%s", prompt)
+	}
+	if strings.Contains(prompt, "Invoices") {
+		t.Error("Low-relevance passage leaked into the prompt")
+	}
+
+	if len(answer.Citations) != 2 || answer.Citations[0].ID != "rotate" || answer.Citations[1].ID != "revoke" {
+		t.Errorf("Unexpected citations %+v", answer.Citations)
+	}
+}
+
+func TestPipelineTokenBudgetAndAssistant(t *testing.T) {
+	long := strings.Repeat("key word ", 400)
+	api := &fakeAPI{vectors: map[string][]float32{"q": {1}}, reply: "ok"}
+	pipeline := &Pipeline{
+		API: api,
+		Retriever: RetrieverFunc(func(ctx context.Context, q Query, k int) ([]Passage, error) {
+			return []Passage{{ID: "long", Text: long}, {ID: "short", Text: "a key fact"}}, nil
+		}),
+		AssistantID:   "asst_1",
+		ContextTokens: 100,
+		SkipRerank:    true,
+	}
+
+	answer, err := pipeline.Answer(context.Background(), "q")
+	if err != nil {
+		t.Fatalf("Answer failed: %v", err)
+	}
+	if len(answer.Passages) != 1 || answer.Passages[0].ID != "short" || answer.Passages[0].Number != 1 {
+		t.Errorf("Expected only the short passage, got %+v", answer.Passages)
+	}
+	if api.chat.AssistantID != "asst_1" || len(api.chat.Messages) != 1 || api.chat.Messages[0].Role != "user" {
+		t.Errorf("Unexpected chat request %+v", api.chat)
+	}
+	if api.rerank.Query != "" {
+		t.Error("SkipRerank should not call Rerank")
+	}
+}
@@ -0,0 +1,34 @@
+package rag
+
+import (
+	"context"
+
+	"github.com/PixiGPT/pixigpt-go/vectorstore"
+)
+
+// VectorStoreRetriever retrieves passages from a vectorstore.Store.
+type VectorStoreRetriever struct {
+	Store  *vectorstore.Store
+	Filter vectorstore.Filter // Optional metadata filter
+}
+
+// Retrieve implements Retriever.
+func (r *VectorStoreRetriever) Retrieve(ctx context.Context, query Query, k int) ([]Passage, error) {
+	results, err := r.Store.Search(query.Vector, k, r.Filter)
+	if err != nil {
+		return nil, err
+	}
+	passages := make([]Passage, len(results))
+	for i, res := range results {
+		passages[i] = Passage{ID: res.ID, Text: res.Text, Score: res.Score, Metadata: res.Metadata}
+	}
+	return passages, nil
+}
+
+// RetrieverFunc adapts a function to Retriever.
+type RetrieverFunc func(ctx context.Context, query Query, k int) ([]Passage, error)
+
+// Retrieve implements Retriever.
+func (f RetrieverFunc) Retrieve(ctx context.Context, query Query, k int) ([]Passage, error) {
+	return f(ctx, query, k)
+}
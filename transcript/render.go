@@ -0,0 +1,216 @@
+package transcript
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/PixiGPT/pixigpt-go/client"
+)
+
+// RenderOptions configures WriteMarkdown and WriteHTML.
+type RenderOptions struct {
+	IncludeReasoning bool           // Include chain of thought in a collapsible block
+	TimeLocation     *time.Location // Time zone for timestamps, default UTC
+}
+
+// WriteMarkdown renders a readable Markdown transcript.
+//
+// Sources become numbered citations, media become embedded links (signed
+// URLs expire after 24h) and code executions become fenced blocks with
+// their output.
+func (t *Transcript) WriteMarkdown(w io.Writer, opts RenderOptions) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Thread %s\n\n", t.Thread.ID)
+	if t.Thread.CreatedAt != 0 {
+		fmt.Fprintf(&b, "_Created %s_\n\n", formatTime(t.Thread.CreatedAt, opts))
+	}
+
+	for _, m := range t.Messages {
+		fmt.Fprintf(&b, "## %s", roleTitle(m.Role))
+		if m.CreatedAt != 0 {
+			fmt.Fprintf(&b, " · %s", formatTime(m.CreatedAt, opts))
+		}
+		b.WriteString("\n\n")
+
+		if opts.IncludeReasoning && m.ReasoningContent != "" {
+			b.WriteString("<details>\n<summary>Reasoning</summary>\n\n")
+			b.WriteString(strings.TrimSpace(m.ReasoningContent))
+			b.WriteString("\n\n</details>\n\n")
+		}
+
+		if text := strings.TrimSpace(m.Text()); text != "" {
+			b.WriteString(text)
+			b.WriteString("\n\n")
+		}
+
+		for _, code := range m.Code {
+			writeFence(&b, code.Language, code.Code)
+			if code.Stdout != nil && *code.Stdout != "" {
+				b.WriteString("Output:\n\n")
+				writeFence(&b, "", *code.Stdout)
+			}
+			if code.Stderr != nil && *code.Stderr != "" {
+				b.WriteString("Errors:\n\n")
+				writeFence(&b, "", *code.Stderr)
+			}
+		}
+
+		for _, media := range m.Media {
+			label := mediaLabel(media)
+			if media.Type == "image" {
+				fmt.Fprintf(&b, "![%s](%s)\n\n", escapeLinkText(label), media.SignedURL)
+			} else {
+				fmt.Fprintf(&b, "[%s: %s](%s)\n\n", media.Type, escapeLinkText(label), media.SignedURL)
+			}
+		}
+
+		if len(m.Sources) > 0 {
+			b.WriteString("**Sources**\n\n")
+			for i, src := range m.Sources {
+				fmt.Fprintf(&b, "%d. %s\n", i+1, sourceMarkdown(src))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteHTML renders a standalone HTML transcript with the same content as
+// WriteMarkdown.
+func (t *Transcript) WriteHTML(w io.Writer, opts RenderOptions) error {
+	return htmlTemplate.Execute(w, struct {
+		*Transcript
+		Opts RenderOptions
+	}{t, opts})
+}
+
+var htmlTemplate = template.Must(template.New("transcript").Funcs(template.FuncMap{
+	"role":  roleTitle,
+	"text":  func(m client.ThreadMessage) string { return m.Text() },
+	"time":  func(ts int64, opts RenderOptions) string { return formatTime(ts, opts) },
+	"label": mediaLabel,
+	"deref": func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Thread {{.Thread.ID}}</title>
+<style>
+body { font-family: sans-serif; max-width: 48em; margin: 2em auto; }
+.message { border-top: 1px solid #ddd; padding: 1em 0; }
+.role { font-weight: bold; }
+.time { color: #888; font-size: 0.9em; }
+.content { white-space: pre-wrap; }
+pre { background: #f5f5f5; padding: 0.5em; overflow-x: auto; }
+img { max-width: 100%; }
+</style>
+</head>
+<body>
+<h1>Thread {{.Thread.ID}}</h1>
+{{- $opts := .Opts}}
+{{- range .Messages}}
+<div class="message {{.Role}}">
+<div><span class="role">{{role .Role}}</span>{{if .CreatedAt}} <span class="time">{{time .CreatedAt $opts}}</span>{{end}}</div>
+{{- if and $opts.IncludeReasoning .ReasoningContent}}
+<details><summary>Reasoning</summary><div class="content">{{.ReasoningContent}}</div></details>
+{{- end}}
+<div class="content">{{text .}}</div>
+{{- range .Code}}
+<pre><code class="language-{{.Language}}">{{.Code}}</code></pre>
+{{- with deref .Stdout}}<p>Output:</p><pre>{{.}}</pre>{{end}}
+{{- with deref .Stderr}}<p>Errors:</p><pre>{{.}}</pre>{{end}}
+{{- end}}
+{{- range .Media}}
+{{- if eq .Type "image"}}
+<figure><img src="{{.SignedURL}}" alt="{{label .}}"><figcaption>{{label .}}</figcaption></figure>
+{{- else}}
+<p><a href="{{.SignedURL}}">{{.Type}}: {{label .}}</a></p>
+{{- end}}
+{{- end}}
+{{- if .Sources}}
+<p><strong>Sources</strong></p>
+<ol>
+{{- range .Sources}}
+<li>{{with deref .URL}}<a href="{{.}}">{{end}}{{with deref .Title}}{{.}}{{else}}{{deref .URL}}{{end}}{{if deref .URL}}</a>{{end}} ({{.ToolName}}){{with deref .Snippet}} — {{.}}{{end}}</li>
+{{- end}}
+</ol>
+{{- end}}
+</div>
+{{- end}}
+</body>
+</html>
+`))
+
+func roleTitle(role string) string {
+	if role == "" {
+		return "Unknown"
+	}
+	return strings.ToUpper(role[:1]) + role[1:]
+}
+
+func formatTime(ts int64, opts RenderOptions) string {
+	loc := opts.TimeLocation
+	if loc == nil {
+		loc = time.UTC
+	}
+	return time.Unix(ts, 0).In(loc).Format("2006-01-02 15:04:05 MST")
+}
+
+// mediaLabel picks the most descriptive text for a media attachment.
+func mediaLabel(m client.MessageMedia) string {
+	switch {
+	case m.Description != nil && *m.Description != "":
+		return *m.Description
+	case m.Prompt != nil && *m.Prompt != "":
+		return *m.Prompt
+	}
+	return m.Source
+}
+
+// sourceMarkdown renders a source as a citation line.
+func sourceMarkdown(src client.MessageSource) string {
+	title := ""
+	if src.Title != nil {
+		title = *src.Title
+	}
+	var line string
+	switch {
+	case src.URL != nil && title != "":
+		line = fmt.Sprintf("[%s](%s)", escapeLinkText(title), *src.URL)
+	case src.URL != nil:
+		line = fmt.Sprintf("<%s>", *src.URL)
+	case title != "":
+		line = title
+	default:
+		line = src.ID
+	}
+	line += fmt.Sprintf(" (%s)", src.ToolName)
+	if src.Snippet != nil && *src.Snippet != "" {
+		line += " — " + strings.Join(strings.Fields(*src.Snippet), " ")
+	}
+	return line
+}
+
+// writeFence writes a fenced code block, lengthening the fence if the
+// content itself contains backticks.
+func writeFence(b *strings.Builder, lang, code string) {
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	fmt.Fprintf(b, "%s%s\n%s\n%s\n\n", fence, lang, strings.TrimRight(code, "\n"), fence)
+}
+
+func escapeLinkText(s string) string {
+	return strings.NewReplacer("[", `\[`, "]", `\]`, "\n", " ").Replace(s)
+}
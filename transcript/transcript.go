@@ -0,0 +1,160 @@
+// Package transcript exports PixiGPT threads as lossless JSON/JSONL and
+// readable Markdown/HTML transcripts, and imports JSON transcripts back into
+// new threads.
+package transcript
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/PixiGPT/pixigpt-go/client"
+)
+
+// Version is the transcript format version written by Export.
+const Version = 1
+
+// Transcript is a complete thread with all messages, oldest first.
+type Transcript struct {
+	Version    int                    `json:"version"`
+	ExportedAt int64                  `json:"exported_at"`
+	Thread     client.Thread          `json:"thread"`
+	Messages   []client.ThreadMessage `json:"messages"`
+}
+
+// API is the subset of *client.Client used by this package.
+type API interface {
+	GetThread(ctx context.Context, threadID string) (*client.Thread, error)
+	ListAllMessages(ctx context.Context, threadID string) ([]client.ThreadMessage, error)
+	CreateThreadWithMetadata(ctx context.Context, metadata map[string]string) (*client.Thread, error)
+	CreateMessagesBulk(ctx context.Context, threadID string, messages []client.BulkMessage) ([]client.ThreadMessage, error)
+}
+
+// Export fetches a thread and every one of its messages.
+func Export(ctx context.Context, api API, threadID string) (*Transcript, error) {
+	thread, err := api.GetThread(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+	messages, err := api.ListAllMessages(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+	return &Transcript{
+		Version:    Version,
+		ExportedAt: time.Now().Unix(),
+		Thread:     *thread,
+		Messages:   messages,
+	}, nil
+}
+
+// WriteJSON writes the transcript as one indented JSON document.
+func (t *Transcript) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(t)
+}
+
+// jsonlHeader is the first line of a JSONL transcript.
+type jsonlHeader struct {
+	Version    int           `json:"version"`
+	ExportedAt int64         `json:"exported_at"`
+	Thread     client.Thread `json:"thread"`
+}
+
+// WriteJSONL writes a header line (version and thread) followed by one
+// message per line.
+func (t *Transcript) WriteJSONL(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(jsonlHeader{Version: t.Version, ExportedAt: t.ExportedAt, Thread: t.Thread}); err != nil {
+		return err
+	}
+	for _, m := range t.Messages {
+		if err := enc.Encode(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadJSON decodes a transcript written by WriteJSON.
+func ReadJSON(r io.Reader) (*Transcript, error) {
+	var t Transcript
+	if err := json.NewDecoder(r).Decode(&t); err != nil {
+		return nil, fmt.Errorf("failed to parse transcript: %w", err)
+	}
+	if err := checkVersion(t.Version); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// ReadJSONL decodes a transcript written by WriteJSONL.
+func ReadJSONL(r io.Reader) (*Transcript, error) {
+	dec := json.NewDecoder(bufio.NewReader(r))
+
+	var header jsonlHeader
+	if err := dec.Decode(&header); err != nil {
+		return nil, fmt.Errorf("failed to parse transcript header: %w", err)
+	}
+	if err := checkVersion(header.Version); err != nil {
+		return nil, err
+	}
+
+	t := &Transcript{Version: header.Version, ExportedAt: header.ExportedAt, Thread: header.Thread}
+	for {
+		var m client.ThreadMessage
+		err := dec.Decode(&m)
+		if errors.Is(err, io.EOF) {
+			return t, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse message %d: %w", len(t.Messages)+1, err)
+		}
+		t.Messages = append(t.Messages, m)
+	}
+}
+
+func checkVersion(v int) error {
+	if v < 1 || v > Version {
+		return fmt.Errorf("unsupported transcript version %d (max %d)", v, Version)
+	}
+	return nil
+}
+
+// ImportOptions configures Import.
+type ImportOptions struct {
+	BatchSize int               // Messages per CreateMessagesBulk call, default 100
+	Metadata  map[string]string // Extra metadata for the new thread
+}
+
+// Import recreates the transcript as a new thread with client.CopyMessages.
+//
+// Roles, text content, tool calls and reasoning are preserved; the new
+// thread's metadata keeps the original metadata plus "source_thread_id".
+func Import(ctx context.Context, api API, t *Transcript, opts ImportOptions) (*client.Thread, error) {
+	metadata := make(map[string]string, len(t.Thread.Metadata)+len(opts.Metadata)+1)
+	for k, v := range t.Thread.Metadata {
+		metadata[k] = v
+	}
+	if t.Thread.ID != "" {
+		metadata["source_thread_id"] = t.Thread.ID
+	}
+	for k, v := range opts.Metadata {
+		metadata[k] = v
+	}
+
+	thread, err := api.CreateThreadWithMetadata(ctx, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.CopyMessages(ctx, api, thread.ID, t.Messages, opts.BatchSize); err != nil {
+		return thread, err
+	}
+	return thread, nil
+}
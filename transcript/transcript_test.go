@@ -0,0 +1,144 @@
+package transcript
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/PixiGPT/pixigpt-go/client"
+)
+
+func ptr(s string) *string { return &s }
+
+func text(s string) []client.MessageContent {
+	return []client.MessageContent{{Type: "text", Text: client.MessageContentText{Value: s}}}
+}
+
+var testTranscript = &Transcript{
+	Version: Version,
+	Thread:  client.Thread{ID: "thread_1", CreatedAt: 1700000000, Metadata: map[string]string{"user": "42"}},
+	Messages: []client.ThreadMessage{
+		{ID: "msg_1", Role: "user", CreatedAt: 1700000000, Content: text("Plot sin(x) and find the news.")},
+		{
+			ID: "msg_2", Role: "assistant", CreatedAt: 1700000060,
+			Content:          text("Here is the plot and a source."),
+			ReasoningContent: "Use Python, then search.",
+			Sources:          []client.MessageSource{{ID: "s1", ToolName: "WebSearch", Title: ptr("News [today]"), URL: ptr("https://example.com/news"), Snippet: ptr("Big\nstory")}},
+			Media:            []client.MessageMedia{{ID: "m1", Source: "DrawImage", Type: "image", Prompt: ptr("sine wave"), SignedURL: "https://r2.example.com/m1.png"}},
+			Code:             []client.MessageCode{{ID: "c1", Language: "python", Code: "print('```')", Stdout: ptr("```")}},
+		},
+	},
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testTranscript.WriteMarkdown(&buf, RenderOptions{IncludeReasoning: true}); err != nil {
+		t.Fatal(err)
+	}
+	md := buf.String()
+	for _, want := range []string{
+		"# Thread thread_1",
+		"## User · 2023-11-14 22:13:20 UTC",
+		"<summary>Reasoning</summary>\n\nUse Python, then search.",
+		"````python\nprint('```')\n````",
+		"![sine wave](https://r2.example.com/m1.png)",
+		"1. [News \\[today\\]](https://example.com/news) (WebSearch) — Big story",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown missing %q:\n%s", want, md)
+		}
+	}
+
+	buf.Reset()
+	testTranscript.WriteMarkdown(&buf, RenderOptions{})
+	if strings.Contains(buf.String(), "Reasoning") {
+		t.Error("Reasoning rendered although not requested")
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testTranscript.WriteHTML(&buf, RenderOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	html := buf.String()
+	for _, want := range []string{
+		`<img src="https://r2.example.com/m1.png" alt="sine wave">`,
+		`<a href="https://example.com/news">News [today]</a> (WebSearch)`,
+		`print(&#39;` + "```" + `&#39;)`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML missing %q:\n%s", want, html)
+		}
+	}
+}
+
+func TestJSONLRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testTranscript.WriteJSONL(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != 3 {
+		t.Errorf("Expected 3 lines, got %d", lines)
+	}
+
+	got, err := ReadJSONL(&buf)
+	if err != nil {
+		t.Fatalf("ReadJSONL failed: %v", err)
+	}
+	if len(got.Messages) != 2 || *got.Messages[1].Code[0].Stdout != "```" || got.Thread.Metadata["user"] != "42" {
+		t.Errorf("Round trip lost data: %+v", got)
+	}
+}
+
+type fakeAPI struct {
+	metadata map[string]string
+	batches  [][]client.BulkMessage
+}
+
+func (f *fakeAPI) GetThread(ctx context.Context, id string) (*client.Thread, error) {
+	return &testTranscript.Thread, nil
+}
+
+func (f *fakeAPI) ListAllMessages(ctx context.Context, id string) ([]client.ThreadMessage, error) {
+	return testTranscript.Messages, nil
+}
+
+func (f *fakeAPI) CreateThreadWithMetadata(ctx context.Context, md map[string]string) (*client.Thread, error) {
+	f.metadata = md
+	return &client.Thread{ID: "thread_new", Metadata: md}, nil
+}
+
+func (f *fakeAPI) CreateMessagesBulk(ctx context.Context, id string, msgs []client.BulkMessage) ([]client.ThreadMessage, error) {
+	f.batches = append(f.batches, append([]client.BulkMessage(nil), msgs...))
+	return nil, nil
+}
+
+func TestExportImport(t *testing.T) {
+	api := &fakeAPI{}
+	ctx := context.Background()
+
+	tr, err := Export(ctx, api, "thread_1")
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	tr.WriteJSON(&buf)
+	tr, err = ReadJSON(&buf)
+	if err != nil {
+		t.Fatalf("ReadJSON failed: %v", err)
+	}
+
+	thread, err := Import(ctx, api, tr, ImportOptions{BatchSize: 1})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if thread.ID != "thread_new" || api.metadata["source_thread_id"] != "thread_1" || api.metadata["user"] != "42" {
+		t.Errorf("Unexpected thread: %+v (metadata %v)", thread, api.metadata)
+	}
+	if len(api.batches) != 2 || api.batches[1][0].Role != "assistant" || api.batches[1][0].Content != "Here is the plot and a source." {
+		t.Errorf("Unexpected batches: %+v", api.batches)
+	}
+}
@@ -0,0 +1,53 @@
+package vectorstore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PixiGPT/pixigpt-go/client"
+)
+
+// Embedder is the subset of *client.Client used to embed queries.
+type Embedder interface {
+	CreateEmbedding(ctx context.Context, req client.EmbeddingRequest) (*client.EmbeddingResponse, error)
+}
+
+// DocumentsFromEmbeddings pairs an embeddings response with document IDs
+// (and optionally texts and metadata, which may be nil), in input order.
+func DocumentsFromEmbeddings(resp *client.EmbeddingResponse, ids, texts []string, metadata []map[string]string) ([]Document, error) {
+	vectors := resp.Vectors()
+	if len(vectors) != len(ids) {
+		return nil, fmt.Errorf("got %d embeddings for %d IDs", len(vectors), len(ids))
+	}
+	if texts != nil && len(texts) != len(ids) {
+		return nil, fmt.Errorf("got %d texts for %d IDs", len(texts), len(ids))
+	}
+	if metadata != nil && len(metadata) != len(ids) {
+		return nil, fmt.Errorf("got %d metadata entries for %d IDs", len(metadata), len(ids))
+	}
+
+	docs := make([]Document, len(ids))
+	for i, id := range ids {
+		docs[i] = Document{ID: id, Vector: vectors[i]}
+		if texts != nil {
+			docs[i].Text = texts[i]
+		}
+		if metadata != nil {
+			docs[i].Metadata = metadata[i]
+		}
+	}
+	return docs, nil
+}
+
+// SearchText embeds query with CreateEmbedding and searches the store.
+func (s *Store) SearchText(ctx context.Context, e Embedder, query string, k int, filter Filter) ([]Result, error) {
+	resp, err := e.CreateEmbedding(ctx, client.NewEmbeddingRequest(query))
+	if err != nil {
+		return nil, err
+	}
+	vectors := resp.Vectors()
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("expected 1 query embedding, got %d", len(vectors))
+	}
+	return s.Search(vectors[0], k, filter)
+}
@@ -0,0 +1,180 @@
+package vectorstore
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// hnswGraph is a Hierarchical Navigable Small World graph over store slots
+// (Malkov & Yashunin, 2016). Deleted slots stay in the graph as routing
+// nodes and are skipped in results until the store compacts and rebuilds
+// the graph.
+type hnswGraph struct {
+	m, m0          int // Max neighbors on upper layers / layer 0
+	efConstruction int
+	efSearch       int
+	levelMult      float64
+	rng            *rand.Rand
+
+	links    [][][]int // slot -> layer -> neighbor slots
+	entry    int
+	maxLevel int
+}
+
+func newHNSWGraph(opts Options, rng *rand.Rand) *hnswGraph {
+	return &hnswGraph{
+		m:              opts.M,
+		m0:             2 * opts.M,
+		efConstruction: opts.EfConstruction,
+		efSearch:       opts.EfSearch,
+		levelMult:      1 / math.Log(float64(max(opts.M, 2))),
+		rng:            rng,
+		entry:          -1,
+	}
+}
+
+// insert links a new slot (always the last one in docs) into the graph.
+func (g *hnswGraph) insert(slot int, docs []Document) {
+	level := int(-math.Log(1-g.rng.Float64()) * g.levelMult)
+	g.links = append(g.links, make([][]int, level+1))
+
+	if g.entry < 0 {
+		g.entry, g.maxLevel = slot, level
+		return
+	}
+
+	q := docs[slot].Vector
+	ep := g.entry
+	for l := g.maxLevel; l > level; l-- {
+		ep = g.greedy(q, ep, l, docs)
+	}
+
+	for l := min(level, g.maxLevel); l >= 0; l-- {
+		candidates := g.searchLayer(q, []int{ep}, g.efConstruction, l, docs)
+		maxLinks := g.m
+		if l == 0 {
+			maxLinks = g.m0
+		}
+
+		neighbors := candidates
+		if len(neighbors) > g.m {
+			neighbors = neighbors[:g.m]
+		}
+		for _, n := range neighbors {
+			g.links[slot][l] = append(g.links[slot][l], n.slot)
+			g.links[n.slot][l] = append(g.links[n.slot][l], slot)
+			if len(g.links[n.slot][l]) > maxLinks {
+				g.links[n.slot][l] = g.closest(n.slot, g.links[n.slot][l], maxLinks, docs)
+			}
+		}
+		ep = candidates[0].slot
+	}
+
+	if level > g.maxLevel {
+		g.entry, g.maxLevel = slot, level
+	}
+}
+
+// search returns the best k accepted slots.
+func (g *hnswGraph) search(q []float32, k int, docs []Document, accept func(int) bool) []Result {
+	if g.entry < 0 {
+		return nil
+	}
+	ep := g.entry
+	for l := g.maxLevel; l > 0; l-- {
+		ep = g.greedy(q, ep, l, docs)
+	}
+
+	top := &resultHeap{}
+	for _, c := range g.searchLayer(q, []int{ep}, max(g.efSearch, k), 0, docs) {
+		if !accept(c.slot) {
+			continue
+		}
+		heap.Push(top, c)
+		if top.Len() > k {
+			heap.Pop(top)
+		}
+	}
+	return collect(top, docs)
+}
+
+// greedy walks to the closest node to q on one layer.
+func (g *hnswGraph) greedy(q []float32, ep, layer int, docs []Document) int {
+	best := dot(q, docs[ep].Vector)
+	for changed := true; changed; {
+		changed = false
+		for _, n := range g.links[ep][layer] {
+			if score := dot(q, docs[n].Vector); score > best {
+				best, ep, changed = score, n, true
+			}
+		}
+	}
+	return ep
+}
+
+// searchLayer is a best-first search keeping ef candidates, returned best
+// first.
+func (g *hnswGraph) searchLayer(q []float32, entries []int, ef, layer int, docs []Document) []scored {
+	visited := make(map[int]bool, ef*4)
+	candidates := &maxHeap{}
+	found := &resultHeap{}
+
+	for _, e := range entries {
+		visited[e] = true
+		s := scored{e, dot(q, docs[e].Vector)}
+		heap.Push(candidates, s)
+		heap.Push(found, s)
+	}
+
+	for candidates.Len() > 0 {
+		c := heap.Pop(candidates).(scored)
+		if found.Len() >= ef && c.score < (*found)[0].score {
+			break
+		}
+		for _, n := range g.links[c.slot][layer] {
+			if visited[n] {
+				continue
+			}
+			visited[n] = true
+			score := dot(q, docs[n].Vector)
+			if found.Len() < ef || score > (*found)[0].score {
+				heap.Push(candidates, scored{n, score})
+				heap.Push(found, scored{n, score})
+				if found.Len() > ef {
+					heap.Pop(found)
+				}
+			}
+		}
+	}
+
+	out := make([]scored, found.Len())
+	for i := len(out) - 1; i >= 0; i-- {
+		out[i] = heap.Pop(found).(scored)
+	}
+	return out
+}
+
+// closest keeps the n links of slot nearest to it.
+func (g *hnswGraph) closest(slot int, links []int, n int, docs []Document) []int {
+	v := docs[slot].Vector
+	sort.Slice(links, func(i, j int) bool {
+		return dot(v, docs[links[i]].Vector) > dot(v, docs[links[j]].Vector)
+	})
+	return links[:n]
+}
+
+// maxHeap orders candidates best first.
+type maxHeap []scored
+
+func (h maxHeap) Len() int            { return len(h) }
+func (h maxHeap) Less(i, j int) bool  { return h[i].score > h[j].score }
+func (h maxHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *maxHeap) Push(x interface{}) { *h = append(*h, x.(scored)) }
+func (h *maxHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
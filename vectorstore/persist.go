@@ -0,0 +1,68 @@
+package vectorstore
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/PixiGPT/pixigpt-go/internal/atomicfile"
+)
+
+// snapshotVersion is the file format version written by Save.
+const snapshotVersion = 1
+
+// snapshot is the on-disk form of a store. Only live documents are written;
+// the HNSW graph is rebuilt on load.
+type snapshot struct {
+	Version int
+	Options Options
+	Docs    []Document
+}
+
+// Encode writes the store's options and documents to w (gob encoded).
+func (s *Store) Encode(w io.Writer) error {
+	s.mu.RLock()
+	snap := snapshot{Version: snapshotVersion, Options: s.opts, Docs: make([]Document, 0, s.live)}
+	for slot, doc := range s.docs {
+		if !s.deleted[slot] {
+			snap.Docs = append(snap.Docs, doc)
+		}
+	}
+	s.mu.RUnlock()
+
+	return gob.NewEncoder(w).Encode(snap)
+}
+
+// Decode reads a store written by Encode.
+func Decode(r io.Reader) (*Store, error) {
+	var snap snapshot
+	if err := gob.NewDecoder(r).Decode(&snap); err != nil {
+		return nil, fmt.Errorf("failed to decode vector store: %w", err)
+	}
+	if snap.Version < 1 || snap.Version > snapshotVersion {
+		return nil, fmt.Errorf("unsupported vector store version %d (max %d)", snap.Version, snapshotVersion)
+	}
+
+	s := New(snap.Options)
+	if err := s.Add(snap.Docs...); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Save writes the store to path atomically. Deleted documents are dropped,
+// so saving and loading also compacts the index.
+func (s *Store) Save(path string) error {
+	return atomicfile.Write(path, s.Encode)
+}
+
+// Load reads a store saved with Save.
+func Load(path string) (*Store, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Decode(f)
+}
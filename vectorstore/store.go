@@ -0,0 +1,394 @@
+// Package vectorstore is an in-memory vector index for PixiGPT embeddings.
+//
+// Documents are keyed by ID, carry string metadata for filtering and are
+// searched by cosine similarity, either exactly (flat scan) or
+// approximately with an HNSW graph for large collections. A Store is safe
+// for concurrent use: searches run in parallel, writes are serialized.
+//
+// Example:
+//
+//	store := vectorstore.New(vectorstore.Options{})
+//	resp, err := c.CreateEmbedding(ctx, client.NewBatchEmbeddingRequest(texts))
+//	docs, err := vectorstore.DocumentsFromEmbeddings(resp, ids, texts, nil)
+//	err = store.Add(docs...)
+//
+//	results, err := store.SearchText(ctx, c, "how do I reset my password?", 5, nil)
+package vectorstore
+
+import (
+	"container/heap"
+	"fmt"
+	"maps"
+	"math"
+	"math/rand"
+	"slices"
+	"sync"
+)
+
+// Algorithm selects the search strategy.
+type Algorithm string
+
+// Search algorithms.
+const (
+	Flat Algorithm = "flat" // Exact scan, best below ~50k documents (default)
+	HNSW Algorithm = "hnsw" // Approximate graph search
+)
+
+// Options configures a Store.
+type Options struct {
+	Algorithm Algorithm
+	// HNSW parameters, ignored for Flat.
+	M              int   // Neighbors per node (default 16)
+	EfConstruction int   // Candidate list size while inserting (default 200)
+	EfSearch       int   // Candidate list size while searching (default 64)
+	Seed           int64 // Level generator seed, 0 = 1 (deterministic)
+}
+
+// Document is an indexed vector with its ID, optional text and metadata.
+type Document struct {
+	ID       string            `json:"id"`
+	Vector   []float32         `json:"vector"`
+	Text     string            `json:"text,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Result is a search hit with its cosine similarity to the query.
+type Result struct {
+	Document
+	Score float32
+}
+
+// Filter selects documents by metadata during search.
+type Filter func(metadata map[string]string) bool
+
+// MatchMetadata returns a filter accepting documents whose metadata
+// contains every key/value pair of want.
+func MatchMetadata(want map[string]string) Filter {
+	return func(metadata map[string]string) bool {
+		for k, v := range want {
+			if metadata[k] != v {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// compactMin is the number of tombstones below which a store never
+// compacts; above it, a store compacts once tombstones outnumber live
+// documents.
+const compactMin = 64
+
+// Store is an in-memory vector index.
+type Store struct {
+	mu   sync.RWMutex
+	opts Options
+	dim  int
+
+	docs    []Document     // Internal slot -> document (vectors normalized)
+	deleted []bool         // Tombstones, kept so HNSW links stay valid until compaction
+	slots   map[string]int // Document ID -> slot
+	live    int
+
+	graph *hnswGraph // nil for Flat
+}
+
+// New creates an empty store.
+func New(opts Options) *Store {
+	if opts.Algorithm == "" {
+		opts.Algorithm = Flat
+	}
+	if opts.M <= 0 {
+		opts.M = 16
+	}
+	if opts.EfConstruction <= 0 {
+		opts.EfConstruction = 200
+	}
+	if opts.EfSearch <= 0 {
+		opts.EfSearch = 64
+	}
+	if opts.Seed == 0 {
+		opts.Seed = 1
+	}
+
+	s := &Store{opts: opts, slots: make(map[string]int)}
+	if opts.Algorithm == HNSW {
+		s.graph = newHNSWGraph(opts, rand.New(rand.NewSource(opts.Seed)))
+	}
+	return s
+}
+
+// Len returns the number of documents.
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.live
+}
+
+// Dimension returns the vector size, 0 while the store is empty.
+func (s *Store) Dimension() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.dim
+}
+
+// Add indexes documents, replacing any with the same ID. Vectors and
+// metadata are copied, vectors normalized; all vectors must have the same
+// dimension. Add is all-or-nothing: an invalid document (or an ID given
+// twice) fails the batch before anything is indexed.
+func (s *Store) Add(docs ...Document) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Validate the whole batch first so a bad document changes nothing
+	dim := s.dim
+	seen := make(map[string]bool, len(docs))
+	for _, doc := range docs {
+		if doc.ID == "" {
+			return fmt.Errorf("document ID is required")
+		}
+		if len(doc.Vector) == 0 {
+			return fmt.Errorf("document %s has no vector", doc.ID)
+		}
+		if dim == 0 {
+			dim = len(doc.Vector)
+		}
+		if len(doc.Vector) != dim {
+			return fmt.Errorf("document %s has dimension %d, store has %d", doc.ID, len(doc.Vector), dim)
+		}
+		if seen[doc.ID] {
+			return fmt.Errorf("document %s appears twice", doc.ID)
+		}
+		seen[doc.ID] = true
+	}
+	s.dim = dim
+
+	for _, doc := range docs {
+		if slot, ok := s.slots[doc.ID]; ok {
+			s.remove(slot)
+		}
+
+		doc.Vector = Normalize(doc.Vector)
+		doc.Metadata = maps.Clone(doc.Metadata)
+		slot := len(s.docs)
+		s.docs = append(s.docs, doc)
+		s.deleted = append(s.deleted, false)
+		s.slots[doc.ID] = slot
+		s.live++
+		if s.graph != nil {
+			s.graph.insert(slot, s.docs)
+		}
+	}
+	s.maybeCompact()
+	return nil
+}
+
+// Delete removes documents by ID and returns how many existed.
+func (s *Store) Delete(ids ...string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := 0
+	for _, id := range ids {
+		if slot, ok := s.slots[id]; ok {
+			s.remove(slot)
+			n++
+		}
+	}
+	s.maybeCompact()
+	return n
+}
+
+func (s *Store) remove(slot int) {
+	delete(s.slots, s.docs[slot].ID)
+	s.deleted[slot] = true
+	s.docs[slot].Metadata = nil
+	s.docs[slot].Text = ""
+	s.live--
+}
+
+// maybeCompact drops tombstones once they pass the threshold, renumbering
+// slots and rebuilding the HNSW graph. An emptied store is reset, dimension
+// included.
+func (s *Store) maybeCompact() {
+	if s.live == 0 && len(s.docs) > 0 {
+		s.dim = 0
+		s.docs, s.deleted = nil, nil
+		s.slots = make(map[string]int)
+		if s.graph != nil {
+			s.graph = newHNSWGraph(s.opts, s.graph.rng)
+		}
+		return
+	}
+
+	tombstones := len(s.docs) - s.live
+	if tombstones < compactMin || tombstones <= s.live {
+		return
+	}
+
+	docs := make([]Document, 0, s.live)
+	for slot, doc := range s.docs {
+		if !s.deleted[slot] {
+			docs = append(docs, doc)
+		}
+	}
+	s.docs = docs
+	s.deleted = make([]bool, len(docs))
+	s.slots = make(map[string]int, len(docs))
+	for slot, doc := range docs {
+		s.slots[doc.ID] = slot
+	}
+
+	if s.graph != nil {
+		s.graph = newHNSWGraph(s.opts, s.graph.rng)
+		for slot := range docs {
+			s.graph.insert(slot, docs)
+		}
+	}
+}
+
+// Get returns a copy of a document by ID. Its vector is normalized.
+func (s *Store) Get(id string) (Document, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	slot, ok := s.slots[id]
+	if !ok {
+		return Document{}, false
+	}
+	return s.docs[slot].clone(), true
+}
+
+// Search returns up to k documents most similar to query, best first.
+// Results hold copies of the documents. A nil filter accepts every
+// document.
+func (s *Store) Search(query []float32, k int, filter Filter) ([]Result, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if k <= 0 || s.live == 0 {
+		return nil, nil
+	}
+	if len(query) != s.dim {
+		return nil, fmt.Errorf("query has dimension %d, store has %d", len(query), s.dim)
+	}
+	q := Normalize(query)
+
+	if s.graph != nil {
+		results := s.graph.search(q, k, s.docs, s.accept(filter))
+		if len(results) >= min(k, s.live) || filter == nil {
+			return results, nil
+		}
+		// Selective filters can starve the graph search; fall back to an
+		// exact scan over the matching documents
+	}
+	return s.flatSearch(q, k, s.accept(filter)), nil
+}
+
+// accept combines tombstones and the user filter.
+func (s *Store) accept(filter Filter) func(slot int) bool {
+	return func(slot int) bool {
+		if s.deleted[slot] {
+			return false
+		}
+		return filter == nil || filter(s.docs[slot].Metadata)
+	}
+}
+
+func (s *Store) flatSearch(q []float32, k int, accept func(int) bool) []Result {
+	top := &resultHeap{}
+	for slot := range s.docs {
+		if !accept(slot) {
+			continue
+		}
+		score := dot(q, s.docs[slot].Vector)
+		if top.Len() < k {
+			heap.Push(top, scored{slot, score})
+		} else if score > (*top)[0].score {
+			(*top)[0] = scored{slot, score}
+			heap.Fix(top, 0)
+		}
+	}
+	return collect(top, s.docs)
+}
+
+// Cosine returns the cosine similarity of a and b (0 if either is zero or
+// their lengths differ).
+func Cosine(a, b []float32) float32 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var ab, aa, bb float64
+	for i := range a {
+		ab += float64(a[i]) * float64(b[i])
+		aa += float64(a[i]) * float64(a[i])
+		bb += float64(b[i]) * float64(b[i])
+	}
+	if aa == 0 || bb == 0 {
+		return 0
+	}
+	return float32(ab / math.Sqrt(aa*bb))
+}
+
+// Normalize returns a unit-length copy of v (a zero copy if v is zero).
+func Normalize(v []float32) []float32 {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	out := make([]float32, len(v))
+	if sum == 0 {
+		return out
+	}
+	inv := 1 / math.Sqrt(sum)
+	for i, x := range v {
+		out[i] = float32(float64(x) * inv)
+	}
+	return out
+}
+
+// clone copies a document's vector and metadata, so callers cannot modify
+// the index through it.
+func (d Document) clone() Document {
+	d.Vector = slices.Clone(d.Vector)
+	d.Metadata = maps.Clone(d.Metadata)
+	return d
+}
+
+func dot(a, b []float32) float32 {
+	var sum float32
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+// scored is a slot with its similarity to the query.
+type scored struct {
+	slot  int
+	score float32
+}
+
+// resultHeap is a min-heap by score, used to keep the best k.
+type resultHeap []scored
+
+func (h resultHeap) Len() int            { return len(h) }
+func (h resultHeap) Less(i, j int) bool  { return h[i].score < h[j].score }
+func (h resultHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *resultHeap) Push(x interface{}) { *h = append(*h, x.(scored)) }
+func (h *resultHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// collect drains a min-heap into results, best first.
+func collect(top *resultHeap, docs []Document) []Result {
+	results := make([]Result, top.Len())
+	for i := len(results) - 1; i >= 0; i-- {
+		s := heap.Pop(top).(scored)
+		results[i] = Result{Document: docs[s.slot].clone(), Score: s.score}
+	}
+	return results
+}
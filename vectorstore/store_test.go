@@ -0,0 +1,331 @@
+package vectorstore
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/PixiGPT/pixigpt-go/client"
+)
+
+func randomDocs(n, dim int, seed int64) []Document {
+	rng := rand.New(rand.NewSource(seed))
+	docs := make([]Document, n)
+	for i := range docs {
+		v := make([]float32, dim)
+		for j := range v {
+			v[j] = rng.Float32()*2 - 1
+		}
+		group := "even"
+		if i%2 == 1 {
+			group = "odd"
+		}
+		docs[i] = Document{ID: fmt.Sprintf("doc-%d", i), Vector: v, Metadata: map[string]string{"group": group}}
+	}
+	return docs
+}
+
+// bruteForce returns the IDs of the k nearest documents by cosine.
+func bruteForce(docs []Document, q []float32, k int) []string {
+	type hit struct {
+		id    string
+		score float32
+	}
+	hits := make([]hit, len(docs))
+	for i, d := range docs {
+		hits[i] = hit{d.ID, Cosine(q, d.Vector)}
+	}
+	sort.Slice(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+	ids := make([]string, k)
+	for i := range ids {
+		ids[i] = hits[i].id
+	}
+	return ids
+}
+
+func TestFlatSearchExact(t *testing.T) {
+	docs := randomDocs(300, 16, 1)
+	store := New(Options{})
+	if err := store.Add(docs...); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	q := randomDocs(1, 16, 99)[0].Vector
+	results, err := store.Search(q, 5, nil)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	want := bruteForce(docs, q, 5)
+	for i, r := range results {
+		if r.ID != want[i] {
+			t.Fatalf("Result %d: expected %s, got %s", i, want[i], r.ID)
+		}
+		if i > 0 && r.Score > results[i-1].Score {
+			t.Errorf("Results not sorted by score")
+		}
+	}
+}
+
+func TestHNSWRecall(t *testing.T) {
+	docs := randomDocs(2000, 32, 2)
+	store := New(Options{Algorithm: HNSW})
+	if err := store.Add(docs...); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	queries := randomDocs(50, 32, 3)
+	hits, total := 0, 0
+	for _, q := range queries {
+		results, err := store.Search(q.Vector, 10, nil)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		want := make(map[string]bool)
+		for _, id := range bruteForce(docs, q.Vector, 10) {
+			want[id] = true
+		}
+		for _, r := range results {
+			if want[r.ID] {
+				hits++
+			}
+		}
+		total += 10
+	}
+	if recall := float64(hits) / float64(total); recall < 0.9 {
+		t.Errorf("Recall@10 too low: %.2f", recall)
+	}
+}
+
+func TestFilterDeleteAndReplace(t *testing.T) {
+	for _, algo := range []Algorithm{Flat, HNSW} {
+		t.Run(string(algo), func(t *testing.T) {
+			docs := randomDocs(200, 8, 4)
+			store := New(Options{Algorithm: algo})
+			if err := store.Add(docs...); err != nil {
+				t.Fatalf("Add failed: %v", err)
+			}
+
+			results, _ := store.Search(docs[3].Vector, 10, MatchMetadata(map[string]string{"group": "odd"}))
+			if len(results) != 10 || results[0].ID != "doc-3" {
+				t.Fatalf("Unexpected filtered results %v", results)
+			}
+			for _, r := range results {
+				if r.Metadata["group"] != "odd" {
+					t.Errorf("Filter leaked %s", r.ID)
+				}
+			}
+
+			if n := store.Delete("doc-3", "missing"); n != 1 || store.Len() != 199 {
+				t.Errorf("Delete returned %d, len %d", n, store.Len())
+			}
+			results, _ = store.Search(docs[3].Vector, 1, nil)
+			if results[0].ID == "doc-3" {
+				t.Error("Deleted document returned")
+			}
+
+			replacement := Document{ID: "doc-5", Vector: docs[7].Vector, Text: "moved"}
+			if err := store.Add(replacement); err != nil {
+				t.Fatalf("Add failed: %v", err)
+			}
+			if store.Len() != 199 {
+				t.Errorf("Replace changed length to %d", store.Len())
+			}
+			if got, ok := store.Get("doc-5"); !ok || got.Text != "moved" {
+				t.Errorf("Unexpected replacement %+v", got)
+			}
+		})
+	}
+}
+
+func TestCompactAndCopies(t *testing.T) {
+	for _, algo := range []Algorithm{Flat, HNSW} {
+		t.Run(string(algo), func(t *testing.T) {
+			docs := randomDocs(300, 8, 5)
+			store := New(Options{Algorithm: algo})
+			if err := store.Add(docs...); err != nil {
+				t.Fatalf("Add failed: %v", err)
+			}
+			ids := make([]string, 200)
+			for i, d := range docs[:200] {
+				ids[i] = d.ID
+			}
+			store.Delete(ids...)
+			if len(store.docs) != 100 || len(store.slots) != 100 || store.Len() != 100 {
+				t.Fatalf("Expected compaction to 100 slots, got %d", len(store.docs))
+			}
+			for _, d := range docs[200:210] {
+				results, err := store.Search(d.Vector, 1, nil)
+				if err != nil || len(results) != 1 || results[0].ID != d.ID {
+					t.Errorf("Expected %s after compaction, got %v (%v)", d.ID, results, err)
+				}
+			}
+
+			docs[251].Metadata["group"] = "changed" // Caller's map after Add
+			got, _ := store.Get("doc-250")
+			got.Vector[0] = 42
+			got.Metadata["group"] = "changed"
+			results, _ := store.Search(docs[251].Vector, 1, nil)
+			results[0].Vector[0] = 42
+			results[0].Metadata["group"] = "changed"
+
+			for id, group := range map[string]string{"doc-250": "even", "doc-251": "odd"} {
+				doc, _ := store.Get(id)
+				if doc.Vector[0] == 42 || doc.Metadata["group"] != group {
+					t.Errorf("%s modified through a returned copy: %+v", id, doc)
+				}
+			}
+		})
+	}
+}
+
+func TestDimensionMismatch(t *testing.T) {
+	store := New(Options{})
+	if err := store.Add(Document{ID: "a", Vector: []float32{1, 0}}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := store.Add(Document{ID: "b", Vector: []float32{1, 0, 0}}); err == nil {
+		t.Error("Expected dimension error on add")
+	}
+	if _, err := store.Search([]float32{1}, 1, nil); err == nil {
+		t.Error("Expected dimension error on search")
+	}
+}
+
+func TestAddIsAllOrNothing(t *testing.T) {
+	store := New(Options{Algorithm: HNSW})
+	good := Document{ID: "a", Vector: []float32{1, 0}}
+	for _, batch := range [][]Document{
+		{good, {ID: "b", Vector: []float32{1, 0, 0}}},
+		{good, {Vector: []float32{0, 1}}},
+		{good, {ID: "a", Vector: []float32{0, 1}}},
+	} {
+		if err := store.Add(batch...); err == nil {
+			t.Errorf("Expected error for batch %+v", batch)
+		}
+	}
+	if store.Len() != 0 || store.Dimension() != 0 || len(store.docs) != 0 || store.graph.entry >= 0 {
+		t.Errorf("Failed batches changed the store: len %d, dim %d", store.Len(), store.Dimension())
+	}
+
+	// Emptying the store frees its dimension
+	if err := store.Add(good); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	store.Delete("a")
+	if store.Dimension() != 0 {
+		t.Errorf("Expected dimension 0 once empty, got %d", store.Dimension())
+	}
+	if err := store.Add(Document{ID: "c", Vector: []float32{1, 0, 0}}); err != nil {
+		t.Errorf("Add with a new dimension failed: %v", err)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	docs := randomDocs(100, 8, 5)
+	store := New(Options{Algorithm: HNSW, M: 8})
+	store.Add(docs...)
+	store.Delete("doc-0")
+
+	path := filepath.Join(t.TempDir(), "index.gob")
+	if err := store.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if loaded.Len() != 99 || loaded.opts.M != 8 || loaded.graph == nil {
+		t.Fatalf("Unexpected loaded store: len %d, opts %+v", loaded.Len(), loaded.opts)
+	}
+	got, ok := loaded.Get("doc-10")
+	if !ok || got.Metadata["group"] != "even" {
+		t.Errorf("Metadata lost: %+v", got)
+	}
+	a, _ := store.Search(docs[10].Vector, 5, nil)
+	b, _ := loaded.Search(docs[10].Vector, 5, nil)
+	if a[0].ID != b[0].ID {
+		t.Errorf("Search differs after load: %s vs %s", a[0].ID, b[0].ID)
+	}
+}
+
+type fakeEmbedder map[string][]float32
+
+func (f fakeEmbedder) CreateEmbedding(ctx context.Context, req client.EmbeddingRequest) (*client.EmbeddingResponse, error) {
+	var inputs []string
+	switch in := req.Input.(type) {
+	case string:
+		inputs = []string{in}
+	case []string:
+		inputs = in
+	}
+	resp := &client.EmbeddingResponse{}
+	for i := len(inputs) - 1; i >= 0; i-- { // Out of order on purpose
+		resp.Data = append(resp.Data, client.EmbeddingData{Index: i, Embedding: f[inputs[i]]})
+	}
+	return resp, nil
+}
+
+func TestEmbeddingsIntegration(t *testing.T) {
+	embedder := fakeEmbedder{
+		"cats purr":     {1, 0, 0},
+		"dogs bark":     {0, 1, 0},
+		"kittens":       {0.9, 0.1, 0},
+		"stock markets": {0, 0, 1},
+	}
+	texts := []string{"cats purr", "dogs bark", "stock markets"}
+	ctx := context.Background()
+
+	resp, _ := embedder.CreateEmbedding(ctx, client.NewBatchEmbeddingRequest(texts))
+	docs, err := DocumentsFromEmbeddings(resp, []string{"c", "d", "s"}, texts, nil)
+	if err != nil {
+		t.Fatalf("DocumentsFromEmbeddings failed: %v", err)
+	}
+	store := New(Options{})
+	store.Add(docs...)
+
+	results, err := store.SearchText(ctx, embedder, "kittens", 1, nil)
+	if err != nil {
+		t.Fatalf("SearchText failed: %v", err)
+	}
+	if results[0].ID != "c" || results[0].Text != "cats purr" {
+		t.Errorf("Unexpected result %+v", results[0])
+	}
+
+	if _, err := DocumentsFromEmbeddings(resp, []string{"c"}, nil, nil); err == nil {
+		t.Error("Expected count mismatch error")
+	}
+}
+
+func TestConcurrentSearch(t *testing.T) {
+	docs := randomDocs(500, 16, 6)
+	store := New(Options{Algorithm: HNSW})
+	store.Add(docs[:250]...)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if _, err := store.Search(docs[(i*50+j)%500].Vector, 5, nil); err != nil {
+					t.Errorf("Search failed: %v", err)
+				}
+			}
+		}(i)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		store.Add(docs[250:]...)
+	}()
+	wg.Wait()
+
+	if store.Len() != 500 {
+		t.Errorf("Expected 500 documents, got %d", store.Len())
+	}
+}